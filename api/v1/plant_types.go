@@ -19,9 +19,15 @@ package v1
 import (
 	"fmt"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // PlantSpec defines the desired state of Plant
@@ -31,26 +37,216 @@ type PlantSpec struct {
 	Image string `json:"image,omitempty"`
 
 	// ContainerPort to expose for host traffic.
-	// Defaults to 80.
+	// Defaults to 80 if Ports are not specified.
 	// +optional
 	ContainerPort *int32 `json:"containerPort,omitempty"`
 
+	// Ports defines a list of named container ports to expose through the Service.
+	// Takes precedence over ContainerPort. The first port is used as the default
+	// target for probes and ingress paths.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Ports []PlantPort `json:"ports,omitempty"`
+
 	// Replicas defines the number of desired pods to deploy.
 	// Defaults to 1.
 	// +kubebuilder:validation:Minimum=1
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Strategy defines how the Deployment replaces existing pods with new ones.
+	// Defaults to RollingUpdate with 25% MaxSurge and MaxUnavailable.
+	// +optional
+	Strategy *RolloutStrategy `json:"strategy,omitempty"`
+
+	// ProgressDeadlineSeconds defines the maximum duration of a rollout before it is considered failed.
+	// Failed rollouts put Plant into Error state. Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// Release defines how image changes are released.
+	// +optional
+	Release *Release `json:"release,omitempty"`
+
+	// Rollback defines how failed rollouts are handled.
+	// +optional
+	Rollback *RollbackPolicy `json:"rollback,omitempty"`
+
+	// RollbackTo requests reverting the Deployment pod template to a revision from Status.History.
+	// Plant pod template is not enforced on the Deployment while it is set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RollbackTo *int64 `json:"rollbackTo,omitempty"`
+
+	// RevisionHistoryLimit defines the number of applied revisions to keep in Status.History.
+	// Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// Autoscaling enables horizontal pod autoscaling of the Deployment.
+	// Replicas are not enforced while autoscaling is enabled.
+	// +optional
+	Autoscaling *Autoscaling `json:"autoscaling,omitempty"`
+
+	// DisruptionBudget defines voluntary disruption limits for Deployment pods.
+	// If not specified, a budget with MaxUnavailable of 1 is created for Plants
+	// running more than a single replica.
+	// +optional
+	DisruptionBudget *DisruptionBudget `json:"disruptionBudget,omitempty"`
+
+	// ImagePullSecrets defines a list of Secrets used to pull images from private registries.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// ServiceAccount defines the identity used by Deployment pods.
+	// +optional
+	ServiceAccount *ServiceAccountConfig `json:"serviceAccount,omitempty"`
+
+	// Command overrides the entrypoint of the Deployment container image.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args overrides the arguments passed to the Deployment container entrypoint.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// WorkingDir overrides the working directory of the Deployment container.
+	// +optional
+	WorkingDir string `json:"workingDir,omitempty"`
+
+	// Lifecycle defines actions to perform after the Deployment container starts
+	// and before it is terminated, e.g. for graceful draining.
+	// +optional
+	Lifecycle *corev1.Lifecycle `json:"lifecycle,omitempty"`
+
+	// TerminationGracePeriodSeconds defines the duration pods are given to terminate gracefully.
+	// Defaults to 30.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// Env defines a list of environment variables to set in the Deployment container.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// EnvFrom defines a list of ConfigMap or Secret sources used to populate
+	// environment variables in the Deployment container.
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// Resources defines compute resource requirements for the Deployment container.
+	// Cannot be specified together with ResourcePreset.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ResourcePreset specifies a named sizing preset used to resolve resources of the
+	// Deployment container. Cannot be specified together with Resources.
+	// +optional
+	ResourcePreset *ResourcePreset `json:"resourcePreset,omitempty"`
+
+	// Volumes defines a list of volumes that can be mounted by the Deployment container.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Volumes []PlantVolume `json:"volumes,omitempty"`
+
+	// VolumeMounts defines a list of Volumes to mount into the Deployment container filesystem.
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// InitContainers defines a list of containers to run to completion before the
+	// Deployment container is started, e.g. for schema migrations.
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// Sidecars defines a list of additional containers to run alongside the
+	// Deployment container, e.g. log shippers or proxies.
+	// +optional
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+
+	// SecurityProfile specifies a Pod Security Standards profile used to render pod and
+	// container security contexts. Containers with own security context are not modified.
+	// If not specified, security contexts are not set.
+	// +optional
+	SecurityProfile *SecurityProfile `json:"securityProfile,omitempty"`
+
+	// PodSecurityContext defines pod-level security attributes for the custom SecurityProfile.
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext defines container-level security attributes for the custom SecurityProfile.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// Probes defines liveness, readiness and startup checks for the Deployment container.
+	// +optional
+	Probes *Probes `json:"probes,omitempty"`
+
+	// NodeSelector defines node labels which must match for pods to be scheduled on a node.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations defines a list of node taints which pods can tolerate.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity defines node and pod scheduling constraints.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints defines how pods are spread across topology domains.
+	// Constraints without label selector select Plant pods.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// HighAvailability enables preferred pod anti-affinity across nodes and zones.
+	// Ignored if Affinity specifies PodAntiAffinity.
+	// +optional
+	HighAvailability bool `json:"highAvailability,omitempty"`
+
+	// Service defines the configuration of the Service exposing the Deployment.
+	// +optional
+	Service *ServiceConfig `json:"service,omitempty"`
+
 	// Host defines the domain name of a network host where the deployed image will be accessible.
 	// Follows RFC 3986 standard.
 	// +kubebuilder:validation:Required
 	Host string `json:"host,omitempty"`
 
+	// AdditionalHosts defines a list of additional domain names where the deployed image will be accessible.
+	// +optional
+	AdditionalHosts []string `json:"additionalHosts,omitempty"`
+
+	// RedirectToHost enables permanent redirects from AdditionalHosts to Host instead of serving them directly.
+	// Requires ingress-nginx Ingress controller in Ingress routing mode.
+	// +optional
+	RedirectToHost bool `json:"redirectToHost,omitempty"`
+
+	// Paths defines a list of ingress routing rules for the host.
+	// Defaults to a single "/" prefix path targeting the first port.
+	// +optional
+	Paths []IngressPath `json:"paths,omitempty"`
+
+	// Routing defines how traffic reaches the Service, either through Ingress or Gateway API.
+	// Defaults to Ingress.
+	// +optional
+	Routing *Routing `json:"routing,omitempty"`
+
 	// IngressClassName specifies the name of the Ingress controller to use. If not set,
 	// it will use cluster default Ingress class.
 	// +optional
 	IngressClassName *string `json:"ingressClassName,omitempty"`
 
+	// IngressOptions configures common ingress controller features. Options are translated
+	// to annotations for the controller of IngressClassName, and are rejected if the
+	// controller cannot express them. Not supported in Gateway routing mode.
+	// +optional
+	IngressOptions *IngressOptions `json:"ingressOptions,omitempty"`
+
 	// TlsSecretName can be used to specify the name of an existing TLS secret for given host.
 	// Specify either TlsSecretName or TlsCertIssuerRef, but not both.
 	// +optional
@@ -77,11 +273,137 @@ type PlantStatus struct {
 	// Resources contains various identifiers about managed objects' states.
 	Resources []ResourceStatus `json:"objects,omitempty"`
 
+	// Replicas defines the total number of observed Deployment pods.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// ReadyReplicas defines the number of ready Deployment pods.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// Selector defines the label selector of Deployment pods in string form.
+	// Used by the scale subresource.
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
+	// URL defines the primary address where the deployed image is accessible.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// LastReadyRevision defines the last Deployment revision which reached Ready state.
+	// +optional
+	LastReadyRevision string `json:"lastReadyRevision,omitempty"`
+
+	// Rollback contains details about the last automatic rollback of a failed rollout.
+	// +optional
+	Rollback *RollbackStatus `json:"rollback,omitempty"`
+
+	// Release contains the state of the current image release.
+	// +optional
+	Release *ReleaseStatus `json:"release,omitempty"`
+
+	// History contains the most recent applied revisions of Plant, ordered from oldest to newest.
+	// +optional
+	History []PlantRevision `json:"history,omitempty"`
+
 	// LastUpdateTime specifies the last time this resource has been updated.
 	// +optional
 	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
 }
 
+// RollbackStatus defines details about an automatic rollback of a failed rollout.
+type RollbackStatus struct {
+	// FailedImage defines the image of the failed rollout.
+	FailedImage string `json:"failedImage,omitempty"`
+
+	// FailedGeneration defines the Plant generation of the failed rollout.
+	FailedGeneration int64 `json:"failedGeneration,omitempty"`
+
+	// FailedTemplateHash defines the hash of the pod template defined by Plant for the failed rollout.
+	// The rolled back revision is kept while Plant defines the same pod template, and the rollback
+	// is cleared once it changes.
+	FailedTemplateHash string `json:"failedTemplateHash,omitempty"`
+
+	// Revision defines the Deployment revision restored by the rollback.
+	Revision string `json:"revision,omitempty"`
+
+	// Time specifies when the rollback was performed.
+	Time metav1.Time `json:"time,omitempty"`
+}
+
+// ReleasePhase defines the state of an image release.
+// +kubebuilder:validation:Enum=Stable;Progressing;Aborted;Failed
+type ReleasePhase string
+
+const (
+	ReleaseStable      ReleasePhase = "Stable"      // ReleaseStable signifies that all traffic is served by the stable Deployment
+	ReleaseProgressing ReleasePhase = "Progressing" // ReleaseProgressing signifies that traffic is split with the canary Deployment
+	ReleaseAborted     ReleasePhase = "Aborted"     // ReleaseAborted signifies that the release was aborted on request
+	ReleaseFailed      ReleasePhase = "Failed"      // ReleaseFailed signifies that the canary Deployment failed to roll out
+)
+
+// ReleaseStatus defines the state of an image release.
+type ReleaseStatus struct {
+	// Phase defines the state of the release.
+	Phase ReleasePhase `json:"phase,omitempty"`
+
+	// StableImage defines the image served by the stable Deployment.
+	StableImage string `json:"stableImage,omitempty"`
+
+	// CanaryImage defines the image being released by the canary Deployment.
+	// +optional
+	CanaryImage string `json:"canaryImage,omitempty"`
+
+	// Step defines the index of the current canary step.
+	// +optional
+	Step int32 `json:"step,omitempty"`
+
+	// Weight defines the percentage of traffic currently sent to the canary Deployment.
+	// +optional
+	Weight int32 `json:"weight,omitempty"`
+
+	// StepStartTime specifies when the current canary step started.
+	// +optional
+	StepStartTime *metav1.Time `json:"stepStartTime,omitempty"`
+}
+
+// RevisionOutcome defines the result of applying a Plant revision.
+// +kubebuilder:validation:Enum=Progressing;Ready;Failed;RolledBack
+type RevisionOutcome string
+
+const (
+	RevisionProgressing RevisionOutcome = "Progressing" // RevisionProgressing signifies that the revision is being rolled out
+	RevisionReady       RevisionOutcome = "Ready"       // RevisionReady signifies that the revision reached Ready state
+	RevisionFailed      RevisionOutcome = "Failed"      // RevisionFailed signifies that the revision failed to roll out
+	RevisionRolledBack  RevisionOutcome = "RolledBack"  // RevisionRolledBack signifies that the failed revision was rolled back
+)
+
+// PlantRevision defines an applied Deployment pod template.
+type PlantRevision struct {
+	// Revision defines the sequence number of the applied spec.
+	Revision int64 `json:"revision"`
+
+	// Image defines the container image of the applied spec.
+	Image string `json:"image"`
+
+	// TemplateHash identifies the applied Deployment pod template.
+	// +optional
+	TemplateHash string `json:"templateHash,omitempty"`
+
+	// DeploymentRevision defines the Deployment revision of the applied pod template once observed.
+	// +optional
+	DeploymentRevision string `json:"deploymentRevision,omitempty"`
+
+	// Generation defines the Plant generation of the applied spec.
+	Generation int64 `json:"generation"`
+
+	// Time specifies when the spec was applied.
+	Time metav1.Time `json:"time"`
+
+	// Outcome defines the result of applying the spec.
+	Outcome RevisionOutcome `json:"outcome"`
+}
+
 // ResourceStatus defines the observed state of Plant-managed or other objects.
 // If more context is required, embed into the object.
 type ResourceStatus struct {
@@ -91,9 +413,593 @@ type ResourceStatus struct {
 	State State     `json:"state,omitempty"`
 }
 
+// PlantPort defines a named port exposed by the Deployment container and Service.
+type PlantPort struct {
+	// Name of the port. Must be a valid IANA_SVC_NAME and unique within Plant.
+	// +kubebuilder:validation:MaxLength=15
+	Name string `json:"name"`
+
+	// ContainerPort defines the port number to expose on the container.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	ContainerPort int32 `json:"containerPort"`
+
+	// Protocol for the port.
+	// Defaults to TCP.
+	// +kubebuilder:validation:Enum=TCP;UDP;SCTP
+	// +optional
+	Protocol corev1.Protocol `json:"protocol,omitempty"`
+}
+
+// BackendProtocol defines the protocol used by ingress controller to connect to pods.
+// +kubebuilder:validation:Enum=HTTP;HTTPS;GRPC;GRPCS
+type BackendProtocol string
+
+const (
+	BackendProtocolHTTP  BackendProtocol = "HTTP"  // BackendProtocolHTTP connects with HTTP/1.1
+	BackendProtocolHTTPS BackendProtocol = "HTTPS" // BackendProtocolHTTPS connects with HTTP/1.1 over TLS
+	BackendProtocolGRPC  BackendProtocol = "GRPC"  // BackendProtocolGRPC connects with gRPC over cleartext HTTP/2
+	BackendProtocolGRPCS BackendProtocol = "GRPCS" // BackendProtocolGRPCS connects with gRPC over TLS
+)
+
+// IngressOptions defines common ingress controller features. Unset options keep controller defaults.
+type IngressOptions struct {
+	// MaxBodySize defines the maximal size of request bodies, e.g. 10Mi. Zero disables the limit.
+	// +optional
+	MaxBodySize *resource.Quantity `json:"maxBodySize,omitempty"`
+
+	// Timeouts defines timeouts of connections to pods.
+	// +optional
+	Timeouts *IngressTimeouts `json:"timeouts,omitempty"`
+
+	// HTTPSRedirect enables or disables permanent redirects of HTTP requests to HTTPS.
+	// +optional
+	HTTPSRedirect *bool `json:"httpsRedirect,omitempty"`
+
+	// CORS enables cross-origin resource sharing responses.
+	// +optional
+	CORS *IngressCORS `json:"cors,omitempty"`
+
+	// RateLimit limits requests per client IP.
+	// +optional
+	RateLimit *IngressRateLimit `json:"rateLimit,omitempty"`
+
+	// BackendProtocol defines the protocol used to connect to pods.
+	// +optional
+	BackendProtocol BackendProtocol `json:"backendProtocol,omitempty"`
+}
+
+// IngressTimeouts defines timeouts of connections to pods.
+type IngressTimeouts struct {
+	// Connect defines the timeout of establishing a connection, e.g. 5s.
+	// +optional
+	Connect *metav1.Duration `json:"connect,omitempty"`
+
+	// Read defines the timeout between two reads of a response, e.g. 60s.
+	// +optional
+	Read *metav1.Duration `json:"read,omitempty"`
+
+	// Send defines the timeout between two writes of a request, e.g. 60s.
+	// +optional
+	Send *metav1.Duration `json:"send,omitempty"`
+}
+
+// IngressCORS defines cross-origin resource sharing responses.
+type IngressCORS struct {
+	// AllowOrigins defines the origins allowed to access the host, e.g. https://example.com.
+	// +kubebuilder:validation:MinItems=1
+	AllowOrigins []string `json:"allowOrigins"`
+
+	// AllowMethods defines the methods allowed in cross-origin requests.
+	// +optional
+	AllowMethods []string `json:"allowMethods,omitempty"`
+
+	// AllowHeaders defines the headers allowed in cross-origin requests.
+	// +optional
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+
+	// AllowCredentials allows cross-origin requests with credentials.
+	// +optional
+	AllowCredentials bool `json:"allowCredentials,omitempty"`
+
+	// MaxAge defines how long preflight responses can be cached.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// IngressRateLimit limits requests per client IP.
+type IngressRateLimit struct {
+	// RequestsPerSecond defines the number of accepted requests per second.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequestsPerSecond *int32 `json:"requestsPerSecond,omitempty"`
+
+	// Connections defines the number of accepted concurrent connections.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Connections *int32 `json:"connections,omitempty"`
+}
+
+// IngressPath defines a routing rule from a host path to a named Plant port.
+type IngressPath struct {
+	// Path is matched against the path of an incoming request.
+	// Defaults to "/".
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// PathType determines the interpretation of the Path matching.
+	// Defaults to Prefix.
+	// +kubebuilder:validation:Enum=Exact;Prefix;ImplementationSpecific
+	// +optional
+	PathType *networkingv1.PathType `json:"pathType,omitempty"`
+
+	// Port specifies the name of the Plant port to route traffic to.
+	// Defaults to the first port.
+	// +optional
+	Port string `json:"port,omitempty"`
+}
+
+// RoutingMode defines the API used to route traffic to Plant.
+// +kubebuilder:validation:Enum=Ingress;Gateway
+type RoutingMode string
+
+const (
+	RoutingModeIngress RoutingMode = "Ingress" // RoutingModeIngress routes traffic through networking.k8s.io Ingress
+	RoutingModeGateway RoutingMode = "Gateway" // RoutingModeGateway routes traffic through Gateway API HTTPRoute
+)
+
+// Routing defines how traffic reaches the Service.
+type Routing struct {
+	// Mode defines the API used to route traffic. Gateway mode creates an HTTPRoute attached
+	// to the referenced Gateway, which terminates TLS on its listeners.
+	// Defaults to Ingress.
+	// +optional
+	Mode RoutingMode `json:"mode,omitempty"`
+
+	// Gateway references the Gateway to attach HTTPRoute to. Required in Gateway mode.
+	// +optional
+	Gateway *GatewayReference `json:"gateway,omitempty"`
+
+	// Matches routes requests satisfying any of the matches to a variant Deployment of
+	// VariantImage, e.g. for A/B testing. Other requests are routed to the Deployment.
+	// In Ingress mode, matches are translated to annotations of the Ingress class.
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	Matches []RouteMatch `json:"matches,omitempty"`
+
+	// VariantImage defines the image of the variant Deployment. Required with Matches.
+	// +optional
+	VariantImage string `json:"variantImage,omitempty"`
+}
+
+// RouteMatchType defines the request attribute checked by RouteMatch.
+// +kubebuilder:validation:Enum=Header;Cookie;QueryParam
+type RouteMatchType string
+
+const (
+	RouteMatchHeader     RouteMatchType = "Header"     // RouteMatchHeader matches requests by header value
+	RouteMatchCookie     RouteMatchType = "Cookie"     // RouteMatchCookie matches requests by cookie value
+	RouteMatchQueryParam RouteMatchType = "QueryParam" // RouteMatchQueryParam matches requests by query parameter value
+)
+
+// RouteMatch selects requests with an exact header, cookie or query parameter value.
+type RouteMatch struct {
+	// Type defines the request attribute to match.
+	Type RouteMatchType `json:"type"`
+
+	// Name defines the name of the header, cookie or query parameter.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+\-.^_\x60|~]+$`
+	Name string `json:"name"`
+
+	// Value defines the exact value to match.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	Value string `json:"value"`
+}
+
+// GatewayReference references a Gateway and optionally its listener.
+type GatewayReference struct {
+	// Name defines the name of the Gateway.
+	Name string `json:"name"`
+
+	// Namespace defines the namespace of the Gateway. Defaults to Plant namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName defines the name of the Gateway listener to attach to.
+	// Attaches to all listeners if not set.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// ServiceConfig defines the configuration of the Service exposing Plant pods.
+type ServiceConfig struct {
+	// Type determines how the Service is exposed.
+	// Defaults to NodePort.
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// Annotations defines additional annotations to add to the Service,
+	// e.g. for cloud load balancer configuration.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// SessionAffinity enables client IP based session affinity.
+	// Defaults to None.
+	// +kubebuilder:validation:Enum=ClientIP;None
+	// +optional
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+
+	// ExternalTrafficPolicy defines how external traffic is routed to node-local or cluster-wide endpoints.
+	// Only applies to NodePort and LoadBalancer types. Defaults to Cluster.
+	// +kubebuilder:validation:Enum=Cluster;Local
+	// +optional
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicyType `json:"externalTrafficPolicy,omitempty"`
+}
+
+// Autoscaling defines horizontal pod autoscaling of the Deployment based on resource utilization.
+// If no targets are specified, defaults to 80% CPU utilization.
+type Autoscaling struct {
+	// MinReplicas defines the lower limit for the number of replicas.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas defines the upper limit for the number of replicas.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Required
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilizationPercentage defines the target average CPU utilization
+	// relative to requested resources. Requires CPU resource requests.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// TargetMemoryUtilizationPercentage defines the target average memory utilization
+	// relative to requested resources. Requires memory resource requests.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
+// RolloutStrategy defines how the Deployment replaces existing pods with new ones.
+type RolloutStrategy struct {
+	// Type of the rollout. Recreate terminates all existing pods before creating new ones.
+	// Defaults to RollingUpdate.
+	// +kubebuilder:validation:Enum=RollingUpdate;Recreate
+	// +optional
+	Type appsv1.DeploymentStrategyType `json:"type,omitempty"`
+
+	// MaxSurge defines the number or percentage of pods that can be created above desired replicas
+	// during RollingUpdate. Defaults to 25%.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable defines the number or percentage of pods that can be unavailable
+	// during RollingUpdate. Defaults to 25%.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// Release defines how image changes are released.
+type Release struct {
+	// Canary enables step-wise canary releases of image changes. The new image is deployed
+	// by a canary Deployment which receives a share of traffic defined by the current step,
+	// and is promoted to the stable Deployment after the last step.
+	// +optional
+	Canary *CanaryRelease `json:"canary,omitempty"`
+}
+
+// CanaryRelease defines the steps of a canary release.
+type CanaryRelease struct {
+	// Steps defines the ordered list of traffic shares sent to the canary Deployment.
+	// A single step with weight 100 performs a blue/green release.
+	// +kubebuilder:validation:MinItems=1
+	Steps []CanaryStep `json:"steps"`
+
+	// Abort stops the current release and sends all traffic to the stable Deployment.
+	// The release is restarted once Abort is unset.
+	// +optional
+	Abort bool `json:"abort,omitempty"`
+
+	// Analysis gates every step on metric queries. The release proceeds only if all
+	// metrics are within their thresholds, and fails if any metric is outside of them.
+	// +optional
+	Analysis *ReleaseAnalysis `json:"analysis,omitempty"`
+}
+
+// ReleaseAnalysis defines metric queries evaluated at the end of every canary step.
+type ReleaseAnalysis struct {
+	// Address defines the URL of a Prometheus-compatible HTTP API, e.g. http://prometheus.monitoring:9090.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Address string `json:"address"`
+
+	// Metrics defines the queries which must be within thresholds for the release to proceed.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Metrics []AnalysisMetric `json:"metrics"`
+}
+
+// AnalysisMetric defines an instant query and its accepted value range.
+type AnalysisMetric struct {
+	// Name defines the name of the metric.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Query defines an instant query returning a single value. The query is a Go template
+	// with fields .Name and .Namespace of the Plant, and .Canary with the name of canary
+	// resources, e.g. sum(rate(http_requests_total{pod=~"{{ .Canary }}-.*",code=~"5.."}[1m])).
+	// Analysis is inconclusive and retried if the query returns no value.
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+
+	// Min defines the minimal accepted value, e.g. "0.99".
+	// +optional
+	Min string `json:"min,omitempty"`
+
+	// Max defines the maximal accepted value, e.g. "0.05".
+	// +optional
+	Max string `json:"max,omitempty"`
+}
+
+// CanaryStep defines a traffic share sent to the canary Deployment.
+type CanaryStep struct {
+	// Weight defines the percentage of traffic sent to the canary Deployment.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// Pause defines the minimal duration of the step. The release proceeds once the pause
+	// has passed and the canary Deployment is ready. Proceeds without pause if not set.
+	// +optional
+	Pause *metav1.Duration `json:"pause,omitempty"`
+}
+
+// RollbackPolicy defines how failed rollouts are handled.
+type RollbackPolicy struct {
+	// Enabled reverts the Deployment to the last revision that reached Ready state
+	// when a rollout exceeds its progress deadline. Plant spec is not enforced on the
+	// Deployment until it changes again.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// DisruptionBudget defines the number of pods that can be unavailable during voluntary disruptions,
+// e.g. node drains. Specify either MinAvailable or MaxUnavailable, but not both.
+type DisruptionBudget struct {
+	// MinAvailable defines the number or percentage of pods that must remain available.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable defines the number or percentage of pods that can be unavailable.
+	// Defaults to 1 if MinAvailable is not specified.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// ServiceAccountConfig defines the ServiceAccount used by Plant pods.
+type ServiceAccountConfig struct {
+	// Name specifies the name of the ServiceAccount. Required if Create is not set.
+	// Defaults to Plant name if Create is set.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Create enables the creation of a ServiceAccount managed by the operator.
+	// +optional
+	Create bool `json:"create,omitempty"`
+
+	// Annotations defines additional annotations to add to the managed ServiceAccount,
+	// e.g. for cloud workload identity.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// AutomountToken specifies whether the ServiceAccount API token is mounted into pods.
+	// +optional
+	AutomountToken *bool `json:"automountToken,omitempty"`
+
+	// Rules defines a list of namespaced permissions granted to the managed ServiceAccount
+	// through a managed Role and RoleBinding. Requires Create, and cannot be used with RoleRef.
+	// +optional
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
+
+	// RoleRef references an existing Role or ClusterRole granted to the managed ServiceAccount
+	// through a managed RoleBinding. Requires Create, and cannot be used with Rules.
+	// +optional
+	RoleRef *ServiceAccountRoleRef `json:"roleRef,omitempty"`
+}
+
+// ServiceAccountRoleRef references an existing Role or ClusterRole.
+type ServiceAccountRoleRef struct {
+	// Kind of the referenced role, either Role or ClusterRole.
+	// +kubebuilder:validation:Enum=Role;ClusterRole
+	// +kubebuilder:default=Role
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the referenced role.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+}
+
+// PlantVolume defines a named volume for the Deployment pods. Specify exactly one volume source.
+type PlantVolume struct {
+	// Name of the volume. Must be a DNS_LABEL and unique within the Plant.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// ConfigMap specifies a ConfigMap that should populate this volume.
+	// +optional
+	ConfigMap *corev1.ConfigMapVolumeSource `json:"configMap,omitempty"`
+
+	// Secret specifies a Secret that should populate this volume.
+	// +optional
+	Secret *corev1.SecretVolumeSource `json:"secret,omitempty"`
+
+	// EmptyDir specifies a temporary directory that shares the pod's lifetime.
+	// +optional
+	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+
+	// PersistentVolumeClaim specifies an existing PersistentVolumeClaim to use.
+	// +optional
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+
+	// ManagedClaim specifies a PersistentVolumeClaim created and managed by the operator.
+	// The claim is named after the Plant. Only one managed claim is supported per Plant.
+	// +optional
+	ManagedClaim *ManagedClaim `json:"managedClaim,omitempty"`
+}
+
+// ManagedClaim defines a PersistentVolumeClaim managed by the operator.
+type ManagedClaim struct {
+	// Size defines the requested storage capacity. Can only be increased.
+	// +kubebuilder:validation:Required
+	Size resource.Quantity `json:"size"`
+
+	// StorageClassName specifies the StorageClass to use. If not set,
+	// it will use cluster default StorageClass.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// AccessModes defines the desired access modes of the volume.
+	// Defaults to ReadWriteOnce.
+	// +optional
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+
+	// RetainOnDelete keeps the claim and its data when the Plant is deleted.
+	// +optional
+	RetainOnDelete bool `json:"retainOnDelete,omitempty"`
+}
+
+// Probes defines health checks performed against the Deployment container.
+type Probes struct {
+	// Liveness defines a check which restarts the container on failure.
+	// +optional
+	Liveness *Probe `json:"liveness,omitempty"`
+
+	// Readiness defines a check which removes the pod from traffic on failure.
+	// +optional
+	Readiness *Probe `json:"readiness,omitempty"`
+
+	// Startup defines a check which delays other checks until the container has started.
+	// +optional
+	Startup *Probe `json:"startup,omitempty"`
+}
+
+// Probe defines a single health check. Specify at most one of HTTPGet, TCPSocket or Exec.
+// If none specified, defaults to TCPSocket check against ContainerPort.
+type Probe struct {
+	// HTTPGet specifies an HTTP GET request to perform.
+	// +optional
+	HTTPGet *HTTPGetProbe `json:"httpGet,omitempty"`
+
+	// TCPSocket specifies a TCP connection to open.
+	// +optional
+	TCPSocket *TCPSocketProbe `json:"tcpSocket,omitempty"`
+
+	// Exec specifies a command to execute inside the container.
+	// +optional
+	Exec *corev1.ExecAction `json:"exec,omitempty"`
+
+	// InitialDelaySeconds defines the number of seconds after the container has started before probes are initiated.
+	// Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// PeriodSeconds defines how often to perform the probe.
+	// Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// TimeoutSeconds defines the number of seconds after which the probe times out.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// SuccessThreshold defines minimum consecutive successes for the probe to be considered successful.
+	// Must be 1 for liveness and startup. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+
+	// FailureThreshold defines minimum consecutive failures for the probe to be considered failed.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// HTTPGetProbe defines an HTTP GET health check.
+type HTTPGetProbe struct {
+	// Path to access on the HTTP server.
+	// Defaults to "/".
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Port to access on the container. Number or name of the port.
+	// Defaults to ContainerPort.
+	// +optional
+	Port *intstr.IntOrString `json:"port,omitempty"`
+
+	// Scheme to use for connecting to the host.
+	// Defaults to HTTP.
+	// +kubebuilder:validation:Enum=HTTP;HTTPS
+	// +optional
+	Scheme corev1.URIScheme `json:"scheme,omitempty"`
+
+	// HTTPHeaders defines custom headers to set in the request.
+	// +optional
+	HTTPHeaders []corev1.HTTPHeader `json:"httpHeaders,omitempty"`
+}
+
+// TCPSocketProbe defines a TCP health check.
+type TCPSocketProbe struct {
+	// Port to access on the container. Number or name of the port.
+	// Defaults to ContainerPort.
+	// +optional
+	Port *intstr.IntOrString `json:"port,omitempty"`
+}
+
+// SecurityProfile defines named Pod Security Standards profiles
+// +kubebuilder:validation:Enum=baseline;restricted;custom
+type SecurityProfile string
+
+const (
+	// SecurityProfileBaseline defines contexts which prevent known privilege escalations.
+	SecurityProfileBaseline SecurityProfile = "baseline"
+	// SecurityProfileRestricted defines contexts which follow pod hardening best practices.
+	SecurityProfileRestricted SecurityProfile = "restricted"
+	// SecurityProfileCustom defines contexts specified in Plant, validated against restricted profile.
+	SecurityProfileCustom SecurityProfile = "custom"
+)
+
+// ResourcePreset defines named compute resource sizes
+// +kubebuilder:validation:Enum=small;medium;large
+type ResourcePreset string
+
+const (
+	// ResourcePresetSmall defines resources for lightweight workloads.
+	ResourcePresetSmall ResourcePreset = "small"
+	// ResourcePresetMedium defines resources for regular workloads.
+	ResourcePresetMedium ResourcePreset = "medium"
+	// ResourcePresetLarge defines resources for heavy workloads.
+	ResourcePresetLarge ResourcePreset = "large"
+)
+
 // ConditionType sets the type to a concrete type for safety.
 type ConditionType string
 
+// ConditionTypeReleaseAnalysis reports the verdict of the last canary release analysis.
+const ConditionTypeReleaseAnalysis ConditionType = "ReleaseAnalysisPassed"
+
 func ConditionTypeAvailableFor(name string) ConditionType {
 	return ConditionType(fmt.Sprintf("%sAvailable", name))
 }
@@ -125,9 +1031,12 @@ type PlantList struct {
 //+genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
 //+kubebuilder:printcolumn:name="Image",type=string,JSONPath=".spec.image"
 //+kubebuilder:printcolumn:name="Host",type=string,JSONPath=".spec.host"
 //+kubebuilder:printcolumn:name="Replicas",type=string,JSONPath=".spec.replicas"
+//+kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=".status.readyReplicas"
+//+kubebuilder:printcolumn:name="URL",type=string,JSONPath=".status.url"
 //+kubebuilder:printcolumn:name="State",type=string,JSONPath=".status.state"
 
 // Plant is the Schema for the plants API.
@@ -1,8 +1,27 @@
 package v1
 
+import (
+	"fmt"
+	"github.com/fhivemind/plant-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
 const (
 	DefaultContainerPort int32 = 80 // DefaultContainerPort defines the default value of ContainerPort for CRD
 	DefaultReplicaCount  int32 = 1  // DefaultReplicaCount defines the default value of Replicas for CRD
+
+	DefaultProgressDeadlineSeconds int32 = 600 // DefaultProgressDeadlineSeconds defines the default value of ProgressDeadlineSeconds for CRD
+	DefaultTargetCPUUtilization    int32 = 80  // DefaultTargetCPUUtilization defines the default value of Autoscaling.TargetCPUUtilizationPercentage for CRD
+	DefaultRevisionHistoryLimit    int32 = 10  // DefaultRevisionHistoryLimit defines the default value of RevisionHistoryLimit for CRD
+
+	DefaultServiceType = corev1.ServiceTypeNodePort // DefaultServiceType defines the default value of Service.Type for CRD
+	DefaultPortName    = "http"                     // DefaultPortName defines the port name used for ContainerPort
+	DefaultPath        = "/"                        // DefaultPath defines the default value of IngressPath.Path for CRD
 )
 
 var (
@@ -11,14 +30,347 @@ var (
 
 	ManagedByLabel = GroupName + "/" + "managed-by" // ManagedByLabel defines a kind-based owner label
 	OwnerNameLabel = GroupName + "/" + "owner-name" // OwnerNameLabel defines a resource-based owner label
+	CanaryOfLabel  = GroupName + "/" + "canary-of"  // CanaryOfLabel defines a resource-based owner label for canary pods
+	VariantOfLabel = GroupName + "/" + "variant-of" // VariantOfLabel defines a resource-based owner label for variant pods
+
+	ConfigHashAnnotation         = GroupName + "/" + "config-hash"         // ConfigHashAnnotation defines a pod template annotation for referenced configuration
+	ManagedAnnotationsAnnotation = GroupName + "/" + "managed-annotations" // ManagedAnnotationsAnnotation defines an annotation listing annotations set by the operator
+	TemplateHashAnnotation       = GroupName + "/" + "template-hash"       // TemplateHashAnnotation defines a Deployment annotation for the pod template hash defined by Plant
 
 	PlantKind     = "Plant"          // PlantKind exports Plant operator kind
 	PlantOperator = "plant-operator" // PlantOperator exports Plant operator name
 )
 
+// ResourcePresets defines compute resource requirements for each ResourcePreset
+var ResourcePresets = map[ResourcePreset]corev1.ResourceRequirements{
+	ResourcePresetSmall:  resourceRequirements("100m", "128Mi", "500m", "256Mi"),
+	ResourcePresetMedium: resourceRequirements("250m", "256Mi", "1", "512Mi"),
+	ResourcePresetLarge:  resourceRequirements("500m", "512Mi", "2", "1Gi"),
+}
+
+func resourceRequirements(cpuRequest, memoryRequest, cpuLimit, memoryLimit string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpuRequest),
+			corev1.ResourceMemory: resource.MustParse(memoryRequest),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpuLimit),
+			corev1.ResourceMemory: resource.MustParse(memoryLimit),
+		},
+	}
+}
+
 func (plant *Plant) OperatorLabels() map[string]string {
 	labels := make(map[string]string)
 	labels[ManagedByLabel] = PlantOperator
 	labels[OwnerNameLabel] = plant.Name
 	return labels
 }
+
+// CanaryLabels returns labels of canary pods. Canary pods are not selected by OperatorLabels.
+func (plant *Plant) CanaryLabels() map[string]string {
+	labels := make(map[string]string)
+	labels[ManagedByLabel] = PlantOperator
+	labels[CanaryOfLabel] = plant.Name
+	return labels
+}
+
+// CanaryName returns the name of canary resources.
+func (plant *Plant) CanaryName() string {
+	return plant.Name + "-canary"
+}
+
+// VariantLabels returns labels of variant pods. Variant pods are not selected by OperatorLabels.
+func (plant *Plant) VariantLabels() map[string]string {
+	labels := make(map[string]string)
+	labels[ManagedByLabel] = PlantOperator
+	labels[VariantOfLabel] = plant.Name
+	return labels
+}
+
+// VariantName returns the name of variant resources.
+func (plant *Plant) VariantName() string {
+	return plant.Name + "-variant"
+}
+
+// VariantActive returns true if requests are routed to a variant Deployment.
+func (plant *Plant) VariantActive() bool {
+	return plant.Spec.Routing != nil && len(plant.Spec.Routing.Matches) > 0 && plant.Spec.Routing.VariantImage != ""
+}
+
+// CanaryActive returns true if a canary release is in progress.
+func (plant *Plant) CanaryActive() bool {
+	return plant.Status.Release != nil && plant.Status.Release.Phase == ReleaseProgressing
+}
+
+// GetStableImage returns the image of the stable Deployment, which differs from Image
+// while the release of Image is not promoted.
+func (plant *Plant) GetStableImage() string {
+	if release := plant.Status.Release; release != nil && release.StableImage != "" {
+		return release.StableImage
+	}
+	return plant.Spec.Image
+}
+
+// GetReleaseAnalysis returns the analysis of canary release steps, or nil if not requested.
+func (plant *Plant) GetReleaseAnalysis() *ReleaseAnalysis {
+	if plant.Spec.Release == nil || plant.Spec.Release.Canary == nil {
+		return nil
+	}
+	return plant.Spec.Release.Canary.Analysis
+}
+
+// RenderQuery returns the metric query for the Plant.
+func (metric *AnalysisMetric) RenderQuery(plant *Plant) (string, error) {
+	tmpl, err := template.New(metric.Name).Option("missingkey=error").Parse(metric.Query)
+	if err != nil {
+		return "", err
+	}
+	var query strings.Builder
+	err = tmpl.Execute(&query, struct{ Name, Namespace, Canary string }{
+		Name:      plant.Name,
+		Namespace: plant.Namespace,
+		Canary:    plant.CanaryName(),
+	})
+	return query.String(), err
+}
+
+// GetMinThreshold returns the parsed Min threshold, or nil if not specified.
+func (metric *AnalysisMetric) GetMinThreshold() (*float64, error) {
+	return parseThreshold(metric.Min)
+}
+
+// GetMaxThreshold returns the parsed Max threshold, or nil if not specified.
+func (metric *AnalysisMetric) GetMaxThreshold() (*float64, error) {
+	return parseThreshold(metric.Max)
+}
+
+// GetPorts returns all ports exposed by Plant. If Ports are not specified,
+// returns a single port named DefaultPortName created from ContainerPort.
+func (plant *Plant) GetPorts() []PlantPort {
+	if len(plant.Spec.Ports) > 0 {
+		ports := make([]PlantPort, len(plant.Spec.Ports))
+		for i, port := range plant.Spec.Ports {
+			ports[i] = port
+			if ports[i].Protocol == "" {
+				ports[i].Protocol = corev1.ProtocolTCP
+			}
+		}
+		return ports
+	}
+
+	containerPort := DefaultContainerPort
+	if plant.Spec.ContainerPort != nil {
+		containerPort = *plant.Spec.ContainerPort
+	}
+	return []PlantPort{{Name: DefaultPortName, ContainerPort: containerPort, Protocol: corev1.ProtocolTCP}}
+}
+
+// GetResources returns compute resource requirements of the Deployment container.
+// Resolves ResourcePreset if specified, otherwise returns Resources.
+func (plant *Plant) GetResources() *corev1.ResourceRequirements {
+	if plant.Spec.ResourcePreset != nil {
+		if preset, ok := ResourcePresets[*plant.Spec.ResourcePreset]; ok {
+			return preset.DeepCopy()
+		}
+	}
+	return plant.Spec.Resources
+}
+
+// GetMinReplicas returns the minimal number of replicas expected to run.
+// Uses Autoscaling.MinReplicas if autoscaling is enabled, otherwise Replicas.
+func (plant *Plant) GetMinReplicas() int32 {
+	if autoscaling := plant.Spec.Autoscaling; autoscaling != nil {
+		if autoscaling.MinReplicas != nil {
+			return *autoscaling.MinReplicas
+		}
+		return DefaultReplicaCount
+	}
+	if plant.Spec.Replicas != nil {
+		return *plant.Spec.Replicas
+	}
+	return DefaultReplicaCount
+}
+
+// GetMaxReplicas returns the maximal number of replicas expected to run.
+// Uses Autoscaling.MaxReplicas if autoscaling is enabled, otherwise Replicas.
+func (plant *Plant) GetMaxReplicas() int32 {
+	if autoscaling := plant.Spec.Autoscaling; autoscaling != nil {
+		return autoscaling.MaxReplicas
+	}
+	if plant.Spec.Replicas != nil {
+		return *plant.Spec.Replicas
+	}
+	return DefaultReplicaCount
+}
+
+// GetURL returns the primary address where the deployed image is accessible.
+// Uses HTTPS scheme if TLS is configured.
+func (plant *Plant) GetURL() string {
+	scheme := "http"
+	if plant.Spec.TlsSecretName != nil || plant.Spec.TlsCertIssuerRef != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, plant.Spec.Host)
+}
+
+// GetRoutingMode returns the configured RoutingMode, or RoutingModeIngress if not set.
+func (plant *Plant) GetRoutingMode() RoutingMode {
+	if plant.Spec.Routing != nil && plant.Spec.Routing.Mode != "" {
+		return plant.Spec.Routing.Mode
+	}
+	return RoutingModeIngress
+}
+
+// GetManagedClaim returns the Volume with ManagedClaim, or nil if not specified.
+func (plant *Plant) GetManagedClaim() *PlantVolume {
+	for i := range plant.Spec.Volumes {
+		if plant.Spec.Volumes[i].ManagedClaim != nil {
+			return &plant.Spec.Volumes[i]
+		}
+	}
+	return nil
+}
+
+// GetServiceAccountName returns the name of the ServiceAccount used by Plant pods,
+// or an empty string if not specified.
+func (plant *Plant) GetServiceAccountName() string {
+	account := plant.Spec.ServiceAccount
+	switch {
+	case account == nil:
+		return ""
+	case account.Name == "" && account.Create:
+		return plant.Name
+	}
+	return account.Name
+}
+
+// GetSecurityContexts returns pod and container security contexts resolved from SecurityProfile.
+// Returns nil contexts if SecurityProfile is not specified.
+func (plant *Plant) GetSecurityContexts() (*corev1.PodSecurityContext, *corev1.SecurityContext) {
+	if plant.Spec.SecurityProfile == nil {
+		return nil, nil
+	}
+	runtimeDefault := &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	switch *plant.Spec.SecurityProfile {
+	case SecurityProfileBaseline:
+		return &corev1.PodSecurityContext{SeccompProfile: runtimeDefault},
+			&corev1.SecurityContext{Privileged: utils.Pointer(false)}
+
+	case SecurityProfileRestricted:
+		return &corev1.PodSecurityContext{RunAsNonRoot: utils.Pointer(true), SeccompProfile: runtimeDefault},
+			&corev1.SecurityContext{
+				Privileged:               utils.Pointer(false),
+				AllowPrivilegeEscalation: utils.Pointer(false),
+				Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			}
+	}
+	return plant.Spec.PodSecurityContext.DeepCopy(), plant.Spec.SecurityContext.DeepCopy()
+}
+
+// RolledBack returns true if the current Plant image failed to roll out and was rolled back.
+func (plant *Plant) RolledBack() bool {
+	return plant.Status.Rollback != nil && plant.Status.Rollback.FailedImage == plant.Spec.Image
+}
+
+// GetRevisionHistoryLimit returns the number of revisions to keep in Status.History.
+func (plant *Plant) GetRevisionHistoryLimit() int {
+	if plant.Spec.RevisionHistoryLimit != nil {
+		return int(*plant.Spec.RevisionHistoryLimit)
+	}
+	return int(DefaultRevisionHistoryLimit)
+}
+
+// GetRevision returns the revision from Status.History, or nil if it is no longer recorded.
+func (plant *Plant) GetRevision(revision int64) *PlantRevision {
+	for i := range plant.Status.History {
+		if plant.Status.History[i].Revision == revision {
+			return &plant.Status.History[i]
+		}
+	}
+	return nil
+}
+
+// GetHosts returns Host followed by all AdditionalHosts.
+func (plant *Plant) GetHosts() []string {
+	return append([]string{plant.Spec.Host}, plant.Spec.AdditionalHosts...)
+}
+
+// ReferencedConfigMaps returns names of all ConfigMaps referenced by Plant.
+func (plant *Plant) ReferencedConfigMaps() []string {
+	names := make(map[string]bool)
+	env, envFrom := plant.allEnv()
+	for _, env := range env {
+		if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil {
+			names[env.ValueFrom.ConfigMapKeyRef.Name] = true
+		}
+	}
+	for _, envFrom := range envFrom {
+		if envFrom.ConfigMapRef != nil {
+			names[envFrom.ConfigMapRef.Name] = true
+		}
+	}
+	for _, volume := range plant.Spec.Volumes {
+		if volume.ConfigMap != nil {
+			names[volume.ConfigMap.Name] = true
+		}
+	}
+	return sortedKeys(names)
+}
+
+// ReferencedSecrets returns names of all Secrets referenced by Plant.
+func (plant *Plant) ReferencedSecrets() []string {
+	names := make(map[string]bool)
+	env, envFrom := plant.allEnv()
+	for _, env := range env {
+		if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+			names[env.ValueFrom.SecretKeyRef.Name] = true
+		}
+	}
+	for _, envFrom := range envFrom {
+		if envFrom.SecretRef != nil {
+			names[envFrom.SecretRef.Name] = true
+		}
+	}
+	for _, volume := range plant.Spec.Volumes {
+		if volume.Secret != nil {
+			names[volume.Secret.SecretName] = true
+		}
+	}
+	return sortedKeys(names)
+}
+
+// allEnv returns environment variables and sources of all Plant containers.
+func (plant *Plant) allEnv() ([]corev1.EnvVar, []corev1.EnvFromSource) {
+	env := append([]corev1.EnvVar{}, plant.Spec.Env...)
+	envFrom := append([]corev1.EnvFromSource{}, plant.Spec.EnvFrom...)
+	for _, containers := range [][]corev1.Container{plant.Spec.InitContainers, plant.Spec.Sidecars} {
+		for _, container := range containers {
+			env = append(env, container.Env...)
+			envFrom = append(envFrom, container.EnvFrom...)
+		}
+	}
+	return env, envFrom
+}
+
+// parseThreshold parses an optional threshold value. Returns nil for empty values.
+func parseThreshold(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid threshold %q", value)
+	}
+	return &threshold, nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -18,15 +18,35 @@ package v1
 
 import (
 	"errors"
+	"fmt"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"path"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sort"
+	"strings"
 )
 
 // log is for logging in this package.
 var plantlog = logf.Log.WithName("plant-resource")
 
+// maxRoutePathsWithMatches defines the maximal number of paths with routing matches, as
+// every path requires two HTTPRoute rules and HTTPRoute allows at most 16 rules
+const maxRoutePathsWithMatches = 8
+
+// IngressClassValidator checks that the Ingress class of Plant can express requested routing features.
+// Ingress class translators are registered by the operator, so it sets the validator before the webhook
+// is started. Ingress class is not validated if the validator is not set.
+var IngressClassValidator func(plant *Plant) error
+
 func (r *Plant) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
@@ -44,7 +64,7 @@ func (r *Plant) Default() {
 	plantlog.Info("default", "name", r.Name)
 
 	// set default ContainerPort
-	if r.Spec.ContainerPort == nil {
+	if r.Spec.ContainerPort == nil && len(r.Spec.Ports) == 0 {
 		r.Spec.ContainerPort = new(int32)
 		*r.Spec.ContainerPort = DefaultContainerPort
 	}
@@ -54,6 +74,18 @@ func (r *Plant) Default() {
 		r.Spec.Replicas = new(int32)
 		*r.Spec.Replicas = DefaultReplicaCount
 	}
+
+	// set default Autoscaling
+	if autoscaling := r.Spec.Autoscaling; autoscaling != nil {
+		if autoscaling.MinReplicas == nil {
+			autoscaling.MinReplicas = new(int32)
+			*autoscaling.MinReplicas = DefaultReplicaCount
+		}
+		if autoscaling.TargetCPUUtilizationPercentage == nil && autoscaling.TargetMemoryUtilizationPercentage == nil {
+			autoscaling.TargetCPUUtilizationPercentage = new(int32)
+			*autoscaling.TargetCPUUtilizationPercentage = DefaultTargetCPUUtilization
+		}
+	}
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
@@ -64,13 +96,34 @@ var _ webhook.Validator = &Plant{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *Plant) ValidateCreate() error {
 	plantlog.Info("validate create", "name", r.Name)
-	return r.validate()
+	if err := r.validate(); err != nil {
+		return err
+	}
+	return r.validateIngressClass()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *Plant) ValidateUpdate(old runtime.Object) error {
 	plantlog.Info("validate update", "name", r.Name)
-	return r.validate()
+	if r.DeletionTimestamp != nil {
+		return nil // allow finalizer removal regardless of spec
+	}
+	oldPlant, ok := old.(*Plant)
+	if ok {
+		if err := r.validateClaimResize(oldPlant); err != nil {
+			return err
+		}
+		if err := r.validateRollbackTo(oldPlant); err != nil {
+			return err
+		}
+	}
+	if err := r.validate(); err != nil {
+		return err
+	}
+	if ok && !r.ingressClassFieldsChanged(oldPlant) {
+		return nil // Ingress class was validated when the fields were set
+	}
+	return r.validateIngressClass()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -88,6 +141,9 @@ func (r *Plant) validate() error {
 	case r.Spec.Host == "":
 		return errors.New(".spec.host is required")
 
+	case r.Spec.RedirectToHost && len(r.Spec.AdditionalHosts) == 0:
+		return errors.New(".spec.redirectToHost requires .spec.additionalHosts")
+
 	case r.Spec.IngressClassName != nil && *r.Spec.IngressClassName == "":
 		return errors.New(".spec.ingressClassName provided but empty")
 
@@ -97,8 +153,673 @@ func (r *Plant) validate() error {
 	case r.Spec.TlsCertIssuerRef != nil && r.Spec.TlsCertIssuerRef.Name == "":
 		return errors.New(".spec.tlsCertIssuerRef.Name cannot be empty")
 
+	case r.Spec.Service != nil && r.Spec.Service.Type == corev1.ServiceTypeClusterIP && r.Spec.Service.ExternalTrafficPolicy != "":
+		return errors.New(".spec.service.externalTrafficPolicy cannot be used with ClusterIP service type")
+
+	case r.Spec.DisruptionBudget != nil && r.Spec.DisruptionBudget.MinAvailable != nil && r.Spec.DisruptionBudget.MaxUnavailable != nil:
+		return errors.New("both .spec.disruptionBudget.minAvailable and .spec.disruptionBudget.maxUnavailable provided but only one required")
+
+	case r.Spec.ServiceAccount != nil && !r.Spec.ServiceAccount.Create && r.Spec.ServiceAccount.Name == "":
+		return errors.New(".spec.serviceAccount.name is required when .spec.serviceAccount.create is not set")
+
+	case r.Spec.ServiceAccount != nil && !r.Spec.ServiceAccount.Create && r.Spec.ServiceAccount.RoleRef != nil:
+		return errors.New(".spec.serviceAccount.roleRef requires .spec.serviceAccount.create")
+
+	case r.Spec.ServiceAccount != nil && !r.Spec.ServiceAccount.Create && len(r.Spec.ServiceAccount.Rules) > 0:
+		return errors.New(".spec.serviceAccount.rules requires .spec.serviceAccount.create")
+
+	case r.Spec.ServiceAccount != nil && r.Spec.ServiceAccount.RoleRef != nil && len(r.Spec.ServiceAccount.Rules) > 0:
+		return errors.New("both .spec.serviceAccount.rules and .spec.serviceAccount.roleRef provided but only one required")
+
+	case (r.Spec.PodSecurityContext != nil || r.Spec.SecurityContext != nil) &&
+		(r.Spec.SecurityProfile == nil || *r.Spec.SecurityProfile != SecurityProfileCustom):
+		return errors.New(".spec.podSecurityContext and .spec.securityContext require custom .spec.securityProfile")
+
+	case r.Spec.Strategy != nil && r.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType &&
+		(r.Spec.Strategy.MaxSurge != nil || r.Spec.Strategy.MaxUnavailable != nil):
+		return errors.New(".spec.strategy.maxSurge and .spec.strategy.maxUnavailable cannot be used with Recreate strategy")
+
+	case r.GetRoutingMode() == RoutingModeGateway && (r.Spec.Routing.Gateway == nil || r.Spec.Routing.Gateway.Name == ""):
+		return errors.New(".spec.routing.gateway.name is required in Gateway routing mode")
+
+	case r.GetRoutingMode() == RoutingModeGateway && r.Spec.IngressClassName != nil:
+		return errors.New(".spec.ingressClassName cannot be used in Gateway routing mode")
+
+	case r.GetRoutingMode() == RoutingModeGateway && (r.Spec.TlsSecretName != nil || r.Spec.TlsCertIssuerRef != nil):
+		return errors.New(".spec.tlsSecretName and .spec.tlsCertIssuerRef cannot be used in Gateway routing mode, TLS is configured on Gateway listeners")
+
 	case r.Spec.TlsSecretName != nil && r.Spec.TlsCertIssuerRef != nil:
 		return errors.New("both .spec.tlsSecretName and .spec.tlsCertIssuerRef provided but only one required")
 	}
+	for _, validateFn := range []func() error{
+		r.validateHosts, r.validatePorts, r.validateEnv, r.validateResources, r.validateProbes,
+		r.validateAutoscaling, r.validateVolumes, r.validateContainers, r.validateLifecycle, r.validateServiceAccount,
+		r.validateSecurity, r.validateScheduling, r.validateRelease, r.validateRouting,
+		r.validateIngressOptions,
+	} {
+		if err := validateFn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateHosts checks that all hosts are non-empty and unique
+func (r *Plant) validateHosts() error {
+	hosts := map[string]bool{r.Spec.Host: true}
+	for i, host := range r.Spec.AdditionalHosts {
+		switch {
+		case host == "":
+			return fmt.Errorf(".spec.additionalHosts[%d] cannot be empty", i)
+
+		case hosts[host]:
+			return fmt.Errorf(".spec.additionalHosts[%d] %q is duplicated", i, host)
+		}
+		hosts[host] = true
+	}
+	return nil
+}
+
+// validatePorts checks that ports are unique and that every ingress path targets a declared TCP port
+func (r *Plant) validatePorts() error {
+	names := make(map[string]bool)
+	numbers := make(map[string]bool)
+	protocols := make(map[string]corev1.Protocol)
+	ports := r.GetPorts()
+	for i, port := range ports {
+		number := fmt.Sprintf("%d/%s", port.ContainerPort, port.Protocol)
+		switch {
+		case len(validation.IsValidPortName(port.Name)) > 0:
+			return fmt.Errorf(".spec.ports[%d].name %q is not a valid port name: %s",
+				i, port.Name, strings.Join(validation.IsValidPortName(port.Name), ", "))
+
+		case names[port.Name]:
+			return fmt.Errorf(".spec.ports[%d].name %q is duplicated", i, port.Name)
+
+		case numbers[number]:
+			return fmt.Errorf(".spec.ports[%d].containerPort %s is duplicated", i, number)
+		}
+		names[port.Name], numbers[number] = true, true
+		protocols[port.Name] = port.Protocol
+	}
+
+	if len(r.Spec.Paths) == 0 && ports[0].Protocol != corev1.ProtocolTCP {
+		return fmt.Errorf(".spec.ports[0] %q must use TCP protocol to be routed by default path", ports[0].Name)
+	}
+	for i, path := range r.Spec.Paths {
+		port := path.Port
+		if port == "" {
+			port = ports[0].Name
+		}
+		switch {
+		case path.Path != "" && !strings.HasPrefix(path.Path, "/"):
+			return fmt.Errorf(".spec.paths[%d].path must start with \"/\"", i)
+
+		case path.Port != "" && !names[path.Port]:
+			return fmt.Errorf(".spec.paths[%d].port %q does not match any declared port", i, path.Port)
+
+		case protocols[port] != corev1.ProtocolTCP:
+			return fmt.Errorf(".spec.paths[%d].port %q must use TCP protocol", i, port)
+
+		case r.GetRoutingMode() == RoutingModeGateway && path.PathType != nil && *path.PathType == networkingv1.PathTypeImplementationSpecific:
+			return fmt.Errorf(".spec.paths[%d].pathType ImplementationSpecific cannot be used in Gateway routing mode", i)
+		}
+	}
+
+	if r.Spec.Probes != nil {
+		for name, probe := range map[string]*Probe{
+			"liveness": r.Spec.Probes.Liveness, "readiness": r.Spec.Probes.Readiness, "startup": r.Spec.Probes.Startup,
+		} {
+			var port *intstr.IntOrString
+			switch {
+			case probe == nil:
+				continue
+			case probe.HTTPGet != nil:
+				port = probe.HTTPGet.Port
+			case probe.TCPSocket != nil:
+				port = probe.TCPSocket.Port
+			}
+			if port != nil && port.Type == intstr.String && !names[port.StrVal] {
+				return fmt.Errorf(".spec.probes.%s port %q does not match any declared port", name, port.StrVal)
+			}
+		}
+	}
+	return nil
+}
+
+// validateResources checks that resources are provided either explicitly or by a preset,
+// and that resource requests do not exceed limits
+func (r *Plant) validateResources() error {
+	if r.Spec.ResourcePreset != nil {
+		if _, ok := ResourcePresets[*r.Spec.ResourcePreset]; !ok {
+			return fmt.Errorf(".spec.resourcePreset %q is not supported", *r.Spec.ResourcePreset)
+		}
+		if r.Spec.Resources != nil {
+			return errors.New("both .spec.resources and .spec.resourcePreset provided but only one required")
+		}
+	}
+	if r.Spec.Resources == nil {
+		return nil
+	}
+	for name, request := range r.Spec.Resources.Requests {
+		if limit, ok := r.Spec.Resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			return fmt.Errorf(".spec.resources.requests.%s must be less than or equal to limit", name)
+		}
+	}
+	return nil
+}
+
+// validateAutoscaling checks replica limits and that utilization targets have matching resource requests
+func (r *Plant) validateAutoscaling() error {
+	autoscaling := r.Spec.Autoscaling
+	if autoscaling == nil {
+		return nil
+	}
+	if autoscaling.MinReplicas != nil && *autoscaling.MinReplicas > autoscaling.MaxReplicas {
+		return errors.New(".spec.autoscaling.minReplicas must be less than or equal to maxReplicas")
+	}
+	targets := []struct {
+		name     string
+		target   *int32
+		resource corev1.ResourceName
+	}{
+		{"targetCPUUtilizationPercentage", autoscaling.TargetCPUUtilizationPercentage, corev1.ResourceCPU},
+		{"targetMemoryUtilizationPercentage", autoscaling.TargetMemoryUtilizationPercentage, corev1.ResourceMemory},
+	}
+	for _, t := range targets {
+		if t.target == nil {
+			continue
+		}
+		// Pods default requests to limits
+		resources := r.GetResources()
+		if resources == nil {
+			return fmt.Errorf(".spec.autoscaling.%s requires .spec.resources.requests.%s", t.name, t.resource)
+		}
+		_, hasRequest := resources.Requests[t.resource]
+		_, hasLimit := resources.Limits[t.resource]
+		if !hasRequest && !hasLimit {
+			return fmt.Errorf(".spec.autoscaling.%s requires .spec.resources.requests.%s", t.name, t.resource)
+		}
+	}
+	return nil
+}
+
+// validateVolumes checks that volumes define a single source and that mounts
+// reference declared volumes without colliding mount paths
+func (r *Plant) validateVolumes() error {
+	names := make(map[string]bool)
+	managedClaims := 0
+	for i, volume := range r.Spec.Volumes {
+		sources := 0
+		for _, defined := range []bool{volume.ConfigMap != nil, volume.Secret != nil, volume.EmptyDir != nil,
+			volume.PersistentVolumeClaim != nil, volume.ManagedClaim != nil} {
+			if defined {
+				sources++
+			}
+		}
+		if volume.ManagedClaim != nil {
+			managedClaims++
+		}
+		switch {
+		case len(validation.IsDNS1123Label(volume.Name)) > 0:
+			return fmt.Errorf(".spec.volumes[%d].name %q is not a valid volume name: %s",
+				i, volume.Name, strings.Join(validation.IsDNS1123Label(volume.Name), ", "))
+
+		case names[volume.Name]:
+			return fmt.Errorf(".spec.volumes[%d].name %q is duplicated", i, volume.Name)
+
+		case sources != 1:
+			return fmt.Errorf(".spec.volumes[%d] must specify exactly one of configMap, secret, emptyDir, persistentVolumeClaim or managedClaim", i)
+
+		case managedClaims > 1:
+			return fmt.Errorf(".spec.volumes[%d].managedClaim provided but only one managed claim supported", i)
+
+		case volume.ManagedClaim != nil && volume.ManagedClaim.Size.Sign() <= 0:
+			return fmt.Errorf(".spec.volumes[%d].managedClaim.size must be positive", i)
+
+		case volume.ManagedClaim != nil && !multiNodeAccess(volume.ManagedClaim.AccessModes) && r.GetMaxReplicas() > 1:
+			return fmt.Errorf(".spec.volumes[%d].managedClaim requires ReadWriteMany or ReadOnlyMany access mode "+
+				"with more than one replica", i)
+
+		case volume.ManagedClaim != nil && !multiNodeAccess(volume.ManagedClaim.AccessModes) && r.Spec.Release != nil &&
+			r.Spec.Release.Canary != nil:
+			return fmt.Errorf(".spec.volumes[%d].managedClaim requires ReadWriteMany or ReadOnlyMany access mode "+
+				"with .spec.release.canary since canary pods mount the claim", i)
+
+		case volume.ManagedClaim != nil && !multiNodeAccess(volume.ManagedClaim.AccessModes) && r.Spec.Routing != nil &&
+			len(r.Spec.Routing.Matches) > 0:
+			return fmt.Errorf(".spec.volumes[%d].managedClaim requires ReadWriteMany or ReadOnlyMany access mode "+
+				"with .spec.routing.matches since variant pods mount the claim", i)
+		}
+		names[volume.Name] = true
+	}
+
+	mountPaths := make(map[string]bool)
+	for i, mount := range r.Spec.VolumeMounts {
+		mountPath := path.Clean(mount.MountPath)
+		switch {
+		case !names[mount.Name]:
+			return fmt.Errorf(".spec.volumeMounts[%d].name %q does not match any declared volume", i, mount.Name)
+
+		case !path.IsAbs(mount.MountPath):
+			return fmt.Errorf(".spec.volumeMounts[%d].mountPath must be an absolute path", i)
+
+		case mountPaths[mountPath]:
+			return fmt.Errorf(".spec.volumeMounts[%d].mountPath %q collides with another mount", i, mount.MountPath)
+		}
+		mountPaths[mountPath] = true
+	}
+	return nil
+}
+
+// multiNodeAccess checks if access modes allow mounting the volume on multiple nodes.
+// Empty access modes default to ReadWriteOnce.
+func multiNodeAccess(accessModes []corev1.PersistentVolumeAccessMode) bool {
+	for _, accessMode := range accessModes {
+		if accessMode == corev1.ReadWriteMany || accessMode == corev1.ReadOnlyMany {
+			return true
+		}
+	}
+	return false
+}
+
+// validateContainers checks that init and sidecar containers have unique names
+// which do not clash with the Deployment container named after Plant
+func (r *Plant) validateContainers() error {
+	names := map[string]bool{r.Name: true}
+	groups := []struct {
+		field      string
+		containers []corev1.Container
+	}{
+		{"initContainers", r.Spec.InitContainers},
+		{"sidecars", r.Spec.Sidecars},
+	}
+	for _, group := range groups {
+		field := group.field
+		for i, container := range group.containers {
+			switch {
+			case len(validation.IsDNS1123Label(container.Name)) > 0:
+				return fmt.Errorf(".spec.%s[%d].name %q is not a valid container name: %s",
+					field, i, container.Name, strings.Join(validation.IsDNS1123Label(container.Name), ", "))
+
+			case container.Name == r.Name:
+				return fmt.Errorf(".spec.%s[%d].name %q clashes with the main container name", field, i, container.Name)
+
+			case names[container.Name]:
+				return fmt.Errorf(".spec.%s[%d].name %q is duplicated", field, i, container.Name)
+
+			case container.Image == "":
+				return fmt.Errorf(".spec.%s[%d].image is required", field, i)
+			}
+			names[container.Name] = true
+		}
+	}
+	return nil
+}
+
+// validateClaimResize checks that managed claim storage is not decreased
+func (r *Plant) validateClaimResize(old *Plant) error {
+	claim, oldClaim := r.GetManagedClaim(), old.GetManagedClaim()
+	if claim == nil || oldClaim == nil {
+		return nil
+	}
+	if claim.ManagedClaim.Size.Cmp(oldClaim.ManagedClaim.Size) < 0 {
+		return fmt.Errorf(".spec.volumes[%s].managedClaim.size cannot be decreased", claim.Name)
+	}
+	return nil
+}
+
+// validateRollbackTo checks that a changed rollback request references a revision recorded in history
+func (r *Plant) validateRollbackTo(old *Plant) error {
+	switch {
+	case r.Spec.RollbackTo == nil:
+		return nil
+	case old.Spec.RollbackTo != nil && *old.Spec.RollbackTo == *r.Spec.RollbackTo:
+		return nil // unchanged, revision may have left history
+	}
+	if old.GetRevision(*r.Spec.RollbackTo) == nil {
+		return fmt.Errorf(".spec.rollbackTo revision %d not found in .status.history", *r.Spec.RollbackTo)
+	}
+	return nil
+}
+
+// validateProbes checks that each probe defines a single valid handler
+func (r *Plant) validateProbes() error {
+	if r.Spec.Probes == nil {
+		return nil
+	}
+	probes := []struct {
+		name          string
+		probe         *Probe
+		singleSuccess bool
+	}{
+		{"liveness", r.Spec.Probes.Liveness, true},
+		{"readiness", r.Spec.Probes.Readiness, false},
+		{"startup", r.Spec.Probes.Startup, true},
+	}
+	for _, p := range probes {
+		if p.probe == nil {
+			continue
+		}
+		handlers := 0
+		for _, defined := range []bool{p.probe.HTTPGet != nil, p.probe.TCPSocket != nil, p.probe.Exec != nil} {
+			if defined {
+				handlers++
+			}
+		}
+		switch {
+		case handlers > 1:
+			return fmt.Errorf(".spec.probes.%s must specify only one of httpGet, tcpSocket or exec", p.name)
+
+		case p.probe.Exec != nil && len(p.probe.Exec.Command) == 0:
+			return fmt.Errorf(".spec.probes.%s.exec.command cannot be empty", p.name)
+
+		case p.singleSuccess && p.probe.SuccessThreshold != nil && *p.probe.SuccessThreshold != 1:
+			return fmt.Errorf(".spec.probes.%s.successThreshold must be 1", p.name)
+		}
+	}
+	return nil
+}
+
+// validateServiceAccount checks that image pull secrets, permission rules and role reference are complete
+func (r *Plant) validateServiceAccount() error {
+	for i, secret := range r.Spec.ImagePullSecrets {
+		if secret.Name == "" {
+			return fmt.Errorf(".spec.imagePullSecrets[%d].name cannot be empty", i)
+		}
+	}
+	if r.Spec.ServiceAccount == nil {
+		return nil
+	}
+	for i, rule := range r.Spec.ServiceAccount.Rules {
+		switch {
+		case len(rule.Verbs) == 0:
+			return fmt.Errorf(".spec.serviceAccount.rules[%d].verbs cannot be empty", i)
+
+		case len(rule.NonResourceURLs) > 0:
+			return fmt.Errorf(".spec.serviceAccount.rules[%d].nonResourceURLs are not supported for namespaced rules", i)
+
+		case len(rule.Resources) == 0:
+			return fmt.Errorf(".spec.serviceAccount.rules[%d].resources cannot be empty", i)
+		}
+	}
+	if ref := r.Spec.ServiceAccount.RoleRef; ref != nil {
+		switch {
+		case ref.Name == "":
+			return errors.New(".spec.serviceAccount.roleRef.name cannot be empty")
+
+		case ref.Kind != "" && ref.Kind != "Role" && ref.Kind != "ClusterRole":
+			return fmt.Errorf(".spec.serviceAccount.roleRef.kind %q must be either Role or ClusterRole", ref.Kind)
+		}
+	}
+	return nil
+}
+
+// validateSecurity checks that custom security contexts of all containers satisfy
+// the restricted Pod Security Standards profile and reports all violated rules
+func (r *Plant) validateSecurity() error {
+	if r.Spec.SecurityProfile == nil || *r.Spec.SecurityProfile != SecurityProfileCustom {
+		return nil
+	}
+	podContext, containerContext := r.GetSecurityContexts()
+	violations := restrictedViolations(".spec.securityContext", podContext, containerContext)
+	for field, containers := range map[string][]corev1.Container{
+		"initContainers": r.Spec.InitContainers, "sidecars": r.Spec.Sidecars,
+	} {
+		for i, container := range containers {
+			if container.SecurityContext != nil {
+				violations = append(violations, restrictedViolations(
+					fmt.Sprintf(".spec.%s[%d].securityContext", field, i), podContext, container.SecurityContext)...)
+			}
+		}
+	}
+	if len(violations) > 0 {
+		sort.Strings(violations)
+		return fmt.Errorf("custom .spec.securityProfile violates restricted profile rules: %s", strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// restrictedViolations returns the restricted profile rules violated by the container running in the pod
+func restrictedViolations(field string, pod *corev1.PodSecurityContext, container *corev1.SecurityContext) []string {
+	if pod == nil {
+		pod = &corev1.PodSecurityContext{}
+	}
+	if container == nil {
+		container = &corev1.SecurityContext{}
+	}
+
+	// resolve effective values, container values take precedence
+	runAsNonRoot, runAsUser, seccompProfile := pod.RunAsNonRoot, pod.RunAsUser, pod.SeccompProfile
+	if container.RunAsNonRoot != nil {
+		runAsNonRoot = container.RunAsNonRoot
+	}
+	if container.RunAsUser != nil {
+		runAsUser = container.RunAsUser
+	}
+	if container.SeccompProfile != nil {
+		seccompProfile = container.SeccompProfile
+	}
+
+	var violations []string
+	if container.Privileged != nil && *container.Privileged {
+		violations = append(violations, field+".privileged must be false")
+	}
+	if container.AllowPrivilegeEscalation == nil || *container.AllowPrivilegeEscalation {
+		violations = append(violations, field+".allowPrivilegeEscalation must be false")
+	}
+	dropsAll := false
+	if capabilities := container.Capabilities; capabilities != nil {
+		for _, capability := range capabilities.Drop {
+			dropsAll = dropsAll || capability == "ALL"
+		}
+		for _, capability := range capabilities.Add {
+			if capability != "NET_BIND_SERVICE" {
+				violations = append(violations, fmt.Sprintf("%s.capabilities.add must not include %s", field, capability))
+			}
+		}
+	}
+	if !dropsAll {
+		violations = append(violations, field+".capabilities.drop must include ALL")
+	}
+	if runAsNonRoot == nil || !*runAsNonRoot {
+		violations = append(violations, field+".runAsNonRoot must be true")
+	}
+	if runAsUser != nil && *runAsUser == 0 {
+		violations = append(violations, field+".runAsUser must not be 0")
+	}
+	if seccompProfile == nil || (seccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault &&
+		seccompProfile.Type != corev1.SeccompProfileTypeLocalhost) {
+		violations = append(violations, field+".seccompProfile.type must be RuntimeDefault or Localhost")
+	}
+	return violations
+}
+
+// validateScheduling checks tolerations and topology spread constraints
+func (r *Plant) validateScheduling() error {
+	for i, toleration := range r.Spec.Tolerations {
+		switch {
+		case toleration.Operator == corev1.TolerationOpExists && toleration.Value != "":
+			return fmt.Errorf(".spec.tolerations[%d].value must be empty when operator is Exists", i)
+
+		case toleration.Key == "" && toleration.Operator != corev1.TolerationOpExists:
+			return fmt.Errorf(".spec.tolerations[%d].operator must be Exists when key is empty", i)
+		}
+	}
+	for i, constraint := range r.Spec.TopologySpreadConstraints {
+		switch {
+		case constraint.TopologyKey == "":
+			return fmt.Errorf(".spec.topologySpreadConstraints[%d].topologyKey cannot be empty", i)
+
+		case constraint.MaxSkew < 1:
+			return fmt.Errorf(".spec.topologySpreadConstraints[%d].maxSkew must be greater than 0", i)
+		}
+	}
+	return nil
+}
+
+// validateLifecycle checks that each lifecycle hook defines a single handler
+func (r *Plant) validateLifecycle() error {
+	if r.Spec.Lifecycle == nil {
+		return nil
+	}
+	for name, handler := range map[string]*corev1.LifecycleHandler{
+		"postStart": r.Spec.Lifecycle.PostStart, "preStop": r.Spec.Lifecycle.PreStop,
+	} {
+		if handler == nil {
+			continue
+		}
+		handlers := 0
+		for _, defined := range []bool{handler.Exec != nil, handler.HTTPGet != nil, handler.TCPSocket != nil} {
+			if defined {
+				handlers++
+			}
+		}
+		switch {
+		case handlers != 1:
+			return fmt.Errorf(".spec.lifecycle.%s must specify exactly one of exec, httpGet or tcpSocket", name)
+
+		case handler.Exec != nil && len(handler.Exec.Command) == 0:
+			return fmt.Errorf(".spec.lifecycle.%s.exec.command cannot be empty", name)
+		}
+	}
+	return nil
+}
+
+// validateEnv checks environment variables for duplicate names and empty references
+func (r *Plant) validateEnv() error {
+	names := make(map[string]bool)
+	for i, env := range r.Spec.Env {
+		switch valueFrom := env.ValueFrom; {
+		case env.Name == "":
+			return fmt.Errorf(".spec.env[%d].name cannot be empty", i)
+
+		case names[env.Name]:
+			return fmt.Errorf(".spec.env[%d].name %q is duplicated", i, env.Name)
+
+		case valueFrom != nil && valueFrom.ConfigMapKeyRef != nil && valueFrom.ConfigMapKeyRef.Name == "":
+			return fmt.Errorf(".spec.env[%d].valueFrom.configMapKeyRef.name cannot be empty", i)
+
+		case valueFrom != nil && valueFrom.SecretKeyRef != nil && valueFrom.SecretKeyRef.Name == "":
+			return fmt.Errorf(".spec.env[%d].valueFrom.secretKeyRef.name cannot be empty", i)
+		}
+		names[env.Name] = true
+	}
+
+	for i, envFrom := range r.Spec.EnvFrom {
+		switch {
+		case envFrom.ConfigMapRef == nil && envFrom.SecretRef == nil:
+			return fmt.Errorf(".spec.envFrom[%d] requires either configMapRef or secretRef", i)
+
+		case envFrom.ConfigMapRef != nil && envFrom.SecretRef != nil:
+			return fmt.Errorf(".spec.envFrom[%d] has both configMapRef and secretRef provided but only one required", i)
+
+		case envFrom.ConfigMapRef != nil && envFrom.ConfigMapRef.Name == "":
+			return fmt.Errorf(".spec.envFrom[%d].configMapRef.name cannot be empty", i)
+
+		case envFrom.SecretRef != nil && envFrom.SecretRef.Name == "":
+			return fmt.Errorf(".spec.envFrom[%d].secretRef.name cannot be empty", i)
+		}
+	}
+	return nil
+}
+
+// validateRouting checks that routing matches have a variant image and that they can be used in
+// the routing mode
+func (r *Plant) validateRouting() error {
+	if r.Spec.Routing == nil || (len(r.Spec.Routing.Matches) == 0 && r.Spec.Routing.VariantImage == "") {
+		return nil
+	}
+	switch {
+	case len(r.Spec.Routing.Matches) == 0:
+		return errors.New(".spec.routing.variantImage requires .spec.routing.matches")
+
+	case r.Spec.Routing.VariantImage == "":
+		return errors.New(".spec.routing.matches requires .spec.routing.variantImage")
+
+	case r.GetRoutingMode() == RoutingModeGateway && len(r.Spec.Paths) > maxRoutePathsWithMatches:
+		return fmt.Errorf(".spec.paths cannot have more than %d entries with .spec.routing.matches in Gateway routing mode",
+			maxRoutePathsWithMatches)
+
+	case r.GetRoutingMode() == RoutingModeIngress && r.Spec.Release != nil && r.Spec.Release.Canary != nil:
+		return errors.New(".spec.routing.matches cannot be used with .spec.release.canary in Ingress routing mode")
+	}
+	return nil
+}
+
+// validateIngressOptions checks that ingress options are valid and used in Ingress routing mode
+func (r *Plant) validateIngressOptions() error {
+	options := r.Spec.IngressOptions
+	if options == nil {
+		return nil
+	}
+	switch {
+	case r.GetRoutingMode() != RoutingModeIngress:
+		return errors.New(".spec.ingressOptions cannot be used in Gateway routing mode")
+
+	case options.MaxBodySize != nil && options.MaxBodySize.Sign() < 0:
+		return errors.New(".spec.ingressOptions.maxBodySize cannot be negative")
+
+	case options.CORS != nil && options.CORS.MaxAge != nil && options.CORS.MaxAge.Duration < 0:
+		return errors.New(".spec.ingressOptions.cors.maxAge cannot be negative")
+	}
+	if timeouts := options.Timeouts; timeouts != nil {
+		for _, timeout := range []struct {
+			name     string
+			duration *metav1.Duration
+		}{{"connect", timeouts.Connect}, {"read", timeouts.Read}, {"send", timeouts.Send}} {
+			if timeout.duration != nil && timeout.duration.Duration <= 0 {
+				return fmt.Errorf(".spec.ingressOptions.timeouts.%s must be positive", timeout.name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateIngressClass checks that the Ingress class can express requested routing features
+// in Ingress routing mode using IngressClassValidator
+func (r *Plant) validateIngressClass() error {
+	if IngressClassValidator == nil || r.GetRoutingMode() != RoutingModeIngress {
+		return nil
+	}
+	return IngressClassValidator(r)
+}
+
+// ingressClassFieldsChanged returns true if the Ingress class or routing features which it has to
+// express changed since old
+func (r *Plant) ingressClassFieldsChanged(old *Plant) bool {
+	return !equality.Semantic.DeepEqual(r.Spec.IngressClassName, old.Spec.IngressClassName) ||
+		r.Spec.Host != old.Spec.Host || r.Spec.RedirectToHost != old.Spec.RedirectToHost ||
+		!equality.Semantic.DeepEqual(r.Spec.Release, old.Spec.Release) ||
+		!equality.Semantic.DeepEqual(r.Spec.Routing, old.Spec.Routing) ||
+		!equality.Semantic.DeepEqual(r.Spec.IngressOptions, old.Spec.IngressOptions)
+}
+
+// validateRelease checks canary release steps
+func (r *Plant) validateRelease() error {
+	if r.Spec.Release == nil || r.Spec.Release.Canary == nil {
+		return nil
+	}
+	for i, step := range r.Spec.Release.Canary.Steps {
+		if step.Pause != nil && step.Pause.Duration < 0 {
+			return fmt.Errorf(".spec.release.canary.steps[%d].pause cannot be negative", i)
+		}
+	}
+	if releaseAnalysis := r.Spec.Release.Canary.Analysis; releaseAnalysis != nil {
+		for i, metric := range releaseAnalysis.Metrics {
+			if metric.Min == "" && metric.Max == "" {
+				return fmt.Errorf(".spec.release.canary.analysis.metrics[%d] requires min or max threshold", i)
+			}
+			if _, err := metric.GetMinThreshold(); err != nil {
+				return fmt.Errorf(".spec.release.canary.analysis.metrics[%d].min: %w", i, err)
+			}
+			if _, err := metric.GetMaxThreshold(); err != nil {
+				return fmt.Errorf(".spec.release.canary.analysis.metrics[%d].max: %w", i, err)
+			}
+			if _, err := metric.RenderQuery(r); err != nil {
+				return fmt.Errorf(".spec.release.canary.analysis.metrics[%d].query is invalid: %w", i, err)
+			}
+		}
+	}
 	return nil
 }
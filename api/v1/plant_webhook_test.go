@@ -0,0 +1,503 @@
+package v1
+
+import (
+	"errors"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/fhivemind/plant-operator/pkg/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"time"
+)
+
+func newValidPlant() *Plant {
+	plant := &Plant{
+		Spec: PlantSpec{
+			Image: "nginx:latest",
+			Host:  "example.com",
+		},
+	}
+	plant.Default()
+	return plant
+}
+
+var _ = Describe("Plant validation", func() {
+	It("Should accept minimal configuration", func() {
+		Expect(newValidPlant().validate()).To(Succeed())
+	})
+
+	It("Should reject duplicate env names", func() {
+		plant := newValidPlant()
+		plant.Spec.Env = []corev1.EnvVar{
+			{Name: "LOG_LEVEL", Value: "debug"},
+			{Name: "LOG_LEVEL", Value: "info"},
+		}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("duplicated")))
+	})
+
+	It("Should reject empty envFrom references", func() {
+		plant := newValidPlant()
+		plant.Spec.EnvFrom = []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{}}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("secretRef.name cannot be empty")))
+
+		plant.Spec.EnvFrom = []corev1.EnvFromSource{{}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("requires either configMapRef or secretRef")))
+	})
+
+	It("Should resolve resources from preset", func() {
+		plant := newValidPlant()
+		preset := ResourcePresetMedium
+		plant.Spec.ResourcePreset = &preset
+		plant.Default()
+
+		expected := ResourcePresets[ResourcePresetMedium]
+		Expect(plant.Spec.Resources).To(BeNil())
+		Expect(plant.GetResources()).To(Equal(&expected))
+		Expect(plant.validate()).To(Succeed())
+
+		plant.Spec.Resources = expected.DeepCopy()
+		Expect(plant.validate()).To(MatchError(ContainSubstring("only one required")))
+	})
+
+	It("Should reject resource requests exceeding limits", func() {
+		plant := newValidPlant()
+		plant.Spec.Resources = &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("requests.cpu")))
+	})
+
+	It("Should reject probes with multiple handlers", func() {
+		plant := newValidPlant()
+		plant.Spec.Probes = &Probes{
+			Readiness: &Probe{
+				HTTPGet:   &HTTPGetProbe{},
+				TCPSocket: &TCPSocketProbe{},
+			},
+		}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("only one of")))
+	})
+
+	It("Should reject ingress paths targeting undeclared ports", func() {
+		plant := newValidPlant()
+		plant.Spec.Ports = []PlantPort{{Name: "http", ContainerPort: 8080}}
+		plant.Spec.Paths = []IngressPath{{Path: "/admin", Port: "admin"}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("does not match any declared port")))
+
+		plant.Spec.Ports = append(plant.Spec.Ports, PlantPort{Name: "admin", ContainerPort: 9901})
+		Expect(plant.validate()).To(Succeed())
+	})
+
+	It("Should reject ingress paths targeting non-TCP ports", func() {
+		plant := newValidPlant()
+		plant.Spec.Ports = []PlantPort{
+			{Name: "http", ContainerPort: 8080},
+			{Name: "dns", ContainerPort: 5353, Protocol: corev1.ProtocolUDP},
+		}
+		plant.Spec.Paths = []IngressPath{{Path: "/dns", Port: "dns"}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("must use TCP protocol")))
+
+		plant.Spec.Paths = []IngressPath{{Path: "/", Port: "http"}}
+		Expect(plant.validate()).To(Succeed())
+
+		plant.Spec.Paths = nil
+		plant.Spec.Ports[0], plant.Spec.Ports[1] = plant.Spec.Ports[1], plant.Spec.Ports[0]
+		Expect(plant.validate()).To(MatchError(ContainSubstring("routed by default path")))
+	})
+
+	It("Should reject duplicate ports", func() {
+		plant := newValidPlant()
+		plant.Spec.Ports = []PlantPort{
+			{Name: "http", ContainerPort: 8080},
+			{Name: "web", ContainerPort: 8080},
+		}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("is duplicated")))
+	})
+
+	It("Should reject duplicate hosts", func() {
+		plant := newValidPlant()
+		plant.Spec.AdditionalHosts = []string{"www.example.com", "example.com"}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("is duplicated")))
+	})
+
+	It("Should reject redirect without additional hosts", func() {
+		plant := newValidPlant()
+		plant.Spec.RedirectToHost = true
+		Expect(plant.validate()).To(MatchError(ContainSubstring("requires .spec.additionalHosts")))
+	})
+
+	It("Should default autoscaling targets", func() {
+		plant := newValidPlant()
+		plant.Spec.Autoscaling = &Autoscaling{MaxReplicas: 3}
+		plant.Default()
+		Expect(*plant.Spec.Autoscaling.MinReplicas).To(Equal(DefaultReplicaCount))
+		Expect(*plant.Spec.Autoscaling.TargetCPUUtilizationPercentage).To(Equal(DefaultTargetCPUUtilization))
+	})
+
+	It("Should reject invalid autoscaling", func() {
+		plant := newValidPlant()
+		plant.Spec.Autoscaling = &Autoscaling{MaxReplicas: 3}
+		plant.Default()
+		Expect(plant.validate()).To(MatchError(ContainSubstring("requires .spec.resources.requests.cpu")))
+
+		plant.Spec.Resources = &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+		}
+		Expect(plant.validate()).To(Succeed())
+
+		plant.Spec.Resources = &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+		}
+		Expect(plant.validate()).To(Succeed())
+
+		*plant.Spec.Autoscaling.MinReplicas = 5
+		Expect(plant.validate()).To(MatchError(ContainSubstring("minReplicas")))
+	})
+
+	It("Should reject disruption budget with both limits", func() {
+		plant := newValidPlant()
+		minAvailable, maxUnavailable := intstr.FromInt(1), intstr.FromInt(1)
+		plant.Spec.DisruptionBudget = &DisruptionBudget{MinAvailable: &minAvailable, MaxUnavailable: &maxUnavailable}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("only one required")))
+	})
+
+	It("Should reject invalid volumes and mounts", func() {
+		plant := newValidPlant()
+		plant.Spec.Volumes = []PlantVolume{{Name: "data"}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("exactly one of")))
+
+		plant.Spec.Volumes = []PlantVolume{
+			{Name: "data", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			{Name: "cache", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}
+		plant.Spec.VolumeMounts = []corev1.VolumeMount{{Name: "logs", MountPath: "/logs"}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("does not match any declared volume")))
+
+		plant.Spec.VolumeMounts = []corev1.VolumeMount{
+			{Name: "data", MountPath: "/data"},
+			{Name: "cache", MountPath: "/data/"},
+		}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("collides")))
+
+		plant.Spec.VolumeMounts[1].MountPath = "/data/cache"
+		Expect(plant.validate()).To(Succeed())
+	})
+
+	It("Should reject managed claim shrinking", func() {
+		old := newValidPlant()
+		old.Spec.Volumes = []PlantVolume{{Name: "data", ManagedClaim: &ManagedClaim{Size: resource.MustParse("2Gi")}}}
+		plant := old.DeepCopy()
+		plant.Spec.Volumes[0].ManagedClaim.Size = resource.MustParse("1Gi")
+		Expect(plant.ValidateUpdate(old)).To(MatchError(ContainSubstring("cannot be decreased")))
+
+		plant.Spec.Volumes[0].ManagedClaim.Size = resource.MustParse("4Gi")
+		Expect(plant.ValidateUpdate(old)).To(Succeed())
+	})
+
+	It("Should reject single node managed claims with multiple replicas", func() {
+		plant := newValidPlant()
+		plant.Spec.Volumes = []PlantVolume{{Name: "data", ManagedClaim: &ManagedClaim{Size: resource.MustParse("1Gi")}}}
+		Expect(plant.validate()).To(Succeed())
+
+		*plant.Spec.Replicas = 2
+		Expect(plant.validate()).To(MatchError(ContainSubstring("requires ReadWriteMany or ReadOnlyMany access mode")))
+
+		*plant.Spec.Replicas = 1
+		plant.Spec.Autoscaling = &Autoscaling{MaxReplicas: 3}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("requires ReadWriteMany or ReadOnlyMany access mode")))
+
+		plant.Spec.Volumes[0].ManagedClaim.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+		Expect(plant.validate()).To(Succeed())
+	})
+
+	It("Should reject single node managed claims with canary releases and routing matches", func() {
+		plant := newValidPlant()
+		className := "nginx"
+		plant.Spec.IngressClassName = &className
+		plant.Spec.Volumes = []PlantVolume{{Name: "data", ManagedClaim: &ManagedClaim{Size: resource.MustParse("1Gi")}}}
+		plant.Spec.Release = &Release{Canary: &CanaryRelease{Steps: []CanaryStep{{Weight: 10}}}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("with .spec.release.canary since canary pods mount the claim")))
+
+		plant.Spec.Release = nil
+		plant.Spec.Routing = &Routing{
+			Matches:      []RouteMatch{{Type: RouteMatchHeader, Name: "X-Variant", Value: "b"}},
+			VariantImage: "nginx:1.23",
+		}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("with .spec.routing.matches since variant pods mount the claim")))
+
+		plant.Spec.Volumes[0].ManagedClaim.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+		Expect(plant.validate()).To(Succeed())
+	})
+
+	It("Should reject container name clashes", func() {
+		plant := newValidPlant()
+		plant.Name = "web"
+		plant.Spec.Sidecars = []corev1.Container{{Name: "web", Image: "envoyproxy/envoy:v1.25.0"}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("clashes with the main container")))
+
+		plant.Spec.Sidecars[0].Name = "proxy"
+		plant.Spec.InitContainers = []corev1.Container{{Name: "proxy", Image: "busybox"}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("is duplicated")))
+
+		plant.Spec.InitContainers[0].Name = "migrate"
+		Expect(plant.validate()).To(Succeed())
+	})
+
+	It("Should reject lifecycle hooks without a single handler", func() {
+		plant := newValidPlant()
+		plant.Spec.Lifecycle = &corev1.Lifecycle{PreStop: &corev1.LifecycleHandler{}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("exactly one of")))
+
+		plant.Spec.Lifecycle.PreStop.Exec = &corev1.ExecAction{Command: []string{"sleep", "5"}}
+		Expect(plant.validate()).To(Succeed())
+	})
+
+	It("Should reject external traffic policy for ClusterIP services", func() {
+		plant := newValidPlant()
+		plant.Spec.Service = &ServiceConfig{
+			Type:                  corev1.ServiceTypeClusterIP,
+			ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+		}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("cannot be used with ClusterIP")))
+
+		plant.Spec.Service.Type = corev1.ServiceTypeLoadBalancer
+		Expect(plant.validate()).To(Succeed())
+	})
+
+	It("Should reject incomplete service account configuration", func() {
+		plant := newValidPlant()
+		plant.Spec.ServiceAccount = &ServiceAccountConfig{}
+		Expect(plant.validate()).To(MatchError(ContainSubstring(".spec.serviceAccount.name is required")))
+
+		plant.Spec.ServiceAccount = &ServiceAccountConfig{Name: "existing", RoleRef: &ServiceAccountRoleRef{}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("requires .spec.serviceAccount.create")))
+
+		plant.Spec.ServiceAccount.Create = true
+		Expect(plant.validate()).To(MatchError(ContainSubstring("roleRef.name cannot be empty")))
+
+		plant.Spec.ServiceAccount.RoleRef = &ServiceAccountRoleRef{Kind: "Group", Name: "view"}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("must be either Role or ClusterRole")))
+
+		plant.Spec.ServiceAccount.RoleRef.Kind = "ClusterRole"
+		Expect(plant.validate()).To(Succeed())
+		Expect(plant.GetServiceAccountName()).To(Equal("existing"))
+
+		plant.Spec.ServiceAccount.Rules = []rbacv1.PolicyRule{{Verbs: []string{"get"}}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("only one required")))
+
+		plant.Spec.ServiceAccount.RoleRef = nil
+		Expect(plant.validate()).To(MatchError(ContainSubstring("rules[0].resources cannot be empty")))
+
+		plant.Spec.ServiceAccount.Rules[0].Resources = []string{"pods"}
+		Expect(plant.validate()).To(Succeed())
+	})
+
+	It("Should report violated restricted rules for custom security profile", func() {
+		plant := newValidPlant()
+		profile := SecurityProfileCustom
+		plant.Spec.SecurityProfile = &profile
+		plant.Spec.SecurityContext = &corev1.SecurityContext{Privileged: utils.Pointer(true)}
+		err := plant.validate()
+		Expect(err).To(MatchError(ContainSubstring("privileged must be false")))
+		Expect(err).To(MatchError(ContainSubstring("capabilities.drop must include ALL")))
+		Expect(err).To(MatchError(ContainSubstring("seccompProfile.type")))
+
+		restricted := SecurityProfileRestricted
+		plant.Spec.SecurityProfile = &restricted
+		podContext, containerContext := plant.GetSecurityContexts()
+		plant.Spec.SecurityProfile = &profile
+		plant.Spec.PodSecurityContext, plant.Spec.SecurityContext = podContext, containerContext
+		Expect(plant.validate()).To(Succeed())
+	})
+
+	It("Should reject security contexts without custom profile", func() {
+		plant := newValidPlant()
+		plant.Spec.SecurityContext = &corev1.SecurityContext{}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("require custom .spec.securityProfile")))
+	})
+
+	It("Should reject invalid scheduling constraints", func() {
+		plant := newValidPlant()
+		plant.Spec.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists, Value: "web"}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("must be empty when operator is Exists")))
+
+		plant.Spec.Tolerations[0].Value = ""
+		plant.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{MaxSkew: 1}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("topologyKey cannot be empty")))
+	})
+
+	It("Should reject rolling parameters for Recreate strategy", func() {
+		plant := newValidPlant()
+		maxSurge := intstr.FromInt(1)
+		plant.Spec.Strategy = &RolloutStrategy{Type: appsv1.RecreateDeploymentStrategyType, MaxSurge: &maxSurge}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("cannot be used with Recreate strategy")))
+
+		plant.Spec.Strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
+		Expect(plant.validate()).To(Succeed())
+	})
+
+	It("Should require Gateway reference in Gateway routing mode", func() {
+		plant := newValidPlant()
+		plant.Spec.Routing = &Routing{Mode: RoutingModeGateway}
+		Expect(plant.validate()).To(MatchError(ContainSubstring(".spec.routing.gateway.name is required")))
+
+		plant.Spec.Routing.Gateway = &GatewayReference{Name: "shared-gateway"}
+		Expect(plant.validate()).To(Succeed())
+
+		className := "nginx"
+		plant.Spec.IngressClassName = &className
+		Expect(plant.validate()).To(MatchError(ContainSubstring("cannot be used in Gateway routing mode")))
+
+		plant.Spec.IngressClassName = nil
+		plant.Spec.TlsCertIssuerRef = &cmmeta.ObjectReference{Name: "custom-issuer"}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("TLS is configured on Gateway listeners")))
+	})
+
+	It("Should reject implementation specific paths in Gateway routing mode", func() {
+		plant := newValidPlant()
+		pathType := networkingv1.PathTypeImplementationSpecific
+		plant.Spec.Paths = []IngressPath{{Path: "/", PathType: &pathType}}
+		Expect(plant.validate()).To(Succeed())
+
+		plant.Spec.Routing = &Routing{Mode: RoutingModeGateway, Gateway: &GatewayReference{Name: "shared-gateway"}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("ImplementationSpecific cannot be used in Gateway routing mode")))
+	})
+
+	It("Should reject negative canary step pause", func() {
+		plant := newValidPlant()
+		className := "nginx"
+		plant.Spec.IngressClassName = &className
+		plant.Spec.Release = &Release{Canary: &CanaryRelease{Steps: []CanaryStep{
+			{Weight: 10, Pause: &metav1.Duration{Duration: time.Minute}},
+			{Weight: 50, Pause: &metav1.Duration{Duration: -time.Minute}},
+		}}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring(".spec.release.canary.steps[1].pause cannot be negative")))
+
+		plant.Spec.Release.Canary.Steps[1].Pause = nil
+		Expect(plant.validate()).To(Succeed())
+	})
+
+	It("Should require variant image with routing matches", func() {
+		plant := newValidPlant()
+		plant.Spec.Routing = &Routing{Matches: []RouteMatch{{Type: RouteMatchHeader, Name: "X-Variant", Value: "b"}}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring(".spec.routing.matches requires .spec.routing.variantImage")))
+
+		plant.Spec.Routing.VariantImage = "nginx:1.23"
+		Expect(plant.validate()).To(Succeed())
+
+		plant.Spec.Routing.Matches = nil
+		Expect(plant.validate()).To(MatchError(ContainSubstring(".spec.routing.variantImage requires .spec.routing.matches")))
+	})
+
+	It("Should reject invalid ingress options", func() {
+		plant := newValidPlant()
+		plant.Spec.IngressOptions = &IngressOptions{
+			BackendProtocol: BackendProtocolGRPC,
+			Timeouts:        &IngressTimeouts{Read: &metav1.Duration{Duration: -time.Second}},
+		}
+		Expect(plant.validate()).To(MatchError(ContainSubstring(".spec.ingressOptions.timeouts.read must be positive")))
+
+		plant.Spec.IngressOptions.Timeouts = nil
+		Expect(plant.validate()).To(Succeed())
+
+		plant.Spec.Routing = &Routing{Mode: RoutingModeGateway, Gateway: &GatewayReference{Name: "shared-gateway"}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring(".spec.ingressOptions cannot be used in Gateway routing mode")))
+	})
+
+	It("Should validate Ingress class only when routing features change", func() {
+		validated := 0
+		IngressClassValidator = func(plant *Plant) error {
+			validated++
+			return errors.New("unknown class")
+		}
+		DeferCleanup(func() { IngressClassValidator = nil })
+
+		plant := newValidPlant()
+		Expect(plant.ValidateCreate()).To(MatchError("unknown class"))
+		Expect(validated).To(Equal(1))
+
+		updated := plant.DeepCopy()
+		updated.Finalizers = []string{"operator.fhivemind.io/finalizer"}
+		*updated.Spec.Replicas = 2
+		Expect(updated.ValidateUpdate(plant)).To(Succeed())
+		Expect(validated).To(Equal(1))
+
+		className := "nginx"
+		updated.Spec.IngressClassName = &className
+		Expect(updated.ValidateUpdate(plant)).To(MatchError("unknown class"))
+		Expect(validated).To(Equal(2))
+
+		updated.Spec.IngressClassName = nil
+		updated.Spec.Routing = &Routing{Mode: RoutingModeGateway, Gateway: &GatewayReference{Name: "shared-gateway"}}
+		Expect(updated.ValidateUpdate(plant)).To(Succeed())
+		Expect(validated).To(Equal(2))
+	})
+
+	It("Should skip validation of deleted Plant", func() {
+		plant := newValidPlant()
+		deleted := plant.DeepCopy()
+		deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		deleted.Finalizers = nil
+		deleted.Spec.Image = ""
+		Expect(deleted.ValidateUpdate(plant)).To(Succeed())
+	})
+
+	It("Should reject routing matches with canary releases in Ingress routing mode", func() {
+		plant := newValidPlant()
+		className := "nginx"
+		plant.Spec.IngressClassName = &className
+		plant.Spec.Routing = &Routing{
+			Matches:      []RouteMatch{{Type: RouteMatchHeader, Name: "X-Variant", Value: "b"}},
+			VariantImage: "nginx:1.23",
+		}
+		plant.Spec.Release = &Release{Canary: &CanaryRelease{Steps: []CanaryStep{{Weight: 10}}}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring("cannot be used with .spec.release.canary in Ingress routing mode")))
+	})
+
+	It("Should reject invalid release analysis metrics", func() {
+		plant := newValidPlant()
+		className := "nginx"
+		plant.Spec.IngressClassName = &className
+		metric := AnalysisMetric{Name: "error-rate", Query: `errors{pod=~"{{ .Canary }}-.*"}`}
+		plant.Spec.Release = &Release{Canary: &CanaryRelease{
+			Steps:    []CanaryStep{{Weight: 10}},
+			Analysis: &ReleaseAnalysis{Address: "http://prometheus:9090", Metrics: []AnalysisMetric{metric}},
+		}}
+		Expect(plant.validate()).To(MatchError(ContainSubstring(".spec.release.canary.analysis.metrics[0] requires min or max threshold")))
+
+		plant.Spec.Release.Canary.Analysis.Metrics[0].Max = "low"
+		Expect(plant.validate()).To(MatchError(ContainSubstring(".spec.release.canary.analysis.metrics[0].max: invalid threshold")))
+
+		plant.Spec.Release.Canary.Analysis.Metrics[0].Max = "0.05"
+		plant.Spec.Release.Canary.Analysis.Metrics[0].Query = `errors{pod=~"{{ .Pod }}"}`
+		Expect(plant.validate()).To(MatchError(ContainSubstring(".spec.release.canary.analysis.metrics[0].query is invalid")))
+
+		plant.Spec.Release.Canary.Analysis.Metrics[0].Query = metric.Query
+		Expect(plant.validate()).To(Succeed())
+		Expect(plant.Spec.Release.Canary.Analysis.Metrics[0].GetMaxThreshold()).To(HaveValue(Equal(0.05)))
+	})
+
+	It("Should reject rollback to unknown revision", func() {
+		plant := newValidPlant()
+		plant.Status.History = []PlantRevision{{Revision: 1, Image: "nginx:1.23", Generation: 1, Outcome: RevisionReady}}
+		old := plant.DeepCopy()
+		revision := int64(2)
+		plant.Spec.RollbackTo = &revision
+		Expect(plant.validateRollbackTo(old)).To(MatchError(ContainSubstring("revision 2 not found")))
+
+		revision = 1
+		Expect(plant.validateRollbackTo(old)).To(Succeed())
+
+		// Unchanged request is kept once the revision leaves history
+		old.Spec.RollbackTo = &revision
+		old.Status.History = nil
+		Expect(plant.validateRollbackTo(old)).To(Succeed())
+	})
+})
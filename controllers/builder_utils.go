@@ -1,16 +1,44 @@
 package controllers
 
 import (
+	"context"
 	"fmt"
 	apiv1 "github.com/fhivemind/plant-operator/api/v1"
 	"github.com/fhivemind/plant-operator/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	configMapRefIndex = ".spec.configMapRefs" // configMapRefIndex indexes Plants by referenced ConfigMap names
+	secretRefIndex    = ".spec.secretRefs"    // secretRefIndex indexes Plants by referenced Secret names
+)
+
+// referencingPlants maps an object to requests for all Plants that reference it through given index
+func (r *PlantReconciler) referencingPlants(index string) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		plants := &apiv1.PlantList{}
+		if err := r.Client.List(context.Background(), plants,
+			client.InNamespace(obj.GetNamespace()), client.MatchingFields{index: obj.GetName()}); err != nil {
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(plants.Items))
+		for _, plant := range plants.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: plant.Namespace, Name: plant.Name},
+			})
+		}
+		return requests
+	}
+}
+
 // notifyWrapper will just inform who triggered the reconcile, usually used for resource tracking
 func notifyWrapper(recorder record.EventRecorder, wrap predicate.Predicate) predicate.Funcs {
 	withMsg := func(should bool, eventType string, obj client.Object) bool {
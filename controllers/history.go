@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"errors"
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"github.com/fhivemind/plant-operator/controllers/workflow"
+	"github.com/fhivemind/plant-operator/pkg/resource"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// recordRevision adds the pod template of the Deployment result to Status.History if it
+// differs from the last recorded one, and updates its outcome from the Deployment result.
+// History is trimmed to the configured limit by removing the oldest revisions.
+func recordRevision(plant *apiv1.Plant, res resource.ExecuteResult) {
+	deployment, ok := res.Object().(*appsv1.Deployment)
+	if !ok || len(deployment.Spec.Template.Spec.Containers) == 0 {
+		return // Deployment not available
+	}
+
+	// Add revision for the applied pod template
+	history := plant.Status.History
+	templateHash := workflow.PodTemplateHash(deployment)
+	if len(history) == 0 || history[len(history)-1].TemplateHash != templateHash {
+		revision := int64(1)
+		if len(history) > 0 {
+			revision = history[len(history)-1].Revision + 1
+		}
+		history = append(history, apiv1.PlantRevision{
+			Revision:     revision,
+			Image:        deployment.Spec.Template.Spec.Containers[0].Image,
+			TemplateHash: templateHash,
+			Generation:   plant.Generation,
+			Time:         metav1.Now(),
+			Outcome:      apiv1.RevisionProgressing,
+		})
+	}
+
+	// Record Deployment revision once the pod template is observed
+	current := &history[len(history)-1]
+	if current.DeploymentRevision == "" && deployment.Status.ObservedGeneration >= deployment.Generation {
+		current.DeploymentRevision = workflow.DeploymentRevision(deployment)
+	}
+
+	// Update outcome of the applied pod template
+	switch {
+	case plant.RolledBack() && current.Image == plant.Status.Rollback.FailedImage:
+		current.Outcome = apiv1.RevisionRolledBack
+	case current.Outcome != apiv1.RevisionProgressing: // outcome already known
+	case res.Ready():
+		current.Outcome = apiv1.RevisionReady
+	case errors.Is(res.Error(), workflow.ProgressDeadlineExceededErr):
+		current.Outcome = apiv1.RevisionFailed
+	}
+
+	// Trim history
+	if limit := plant.GetRevisionHistoryLimit(); len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	plant.Status.History = history
+}
@@ -28,8 +28,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 //+kubebuilder:rbac:groups=operator.fhivemind.io,resources=plants,verbs=get;list;watch;create;update;patch;delete
@@ -37,12 +39,28 @@ import (
 //+kubebuilder:rbac:groups=operator.fhivemind.io,resources=plants/finalizers,verbs=update
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,resources=deployments/status,verbs=get
+//+kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=services/status,verbs=get
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/status,verbs=get
 //+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=cert-manager.io,resources=certificates/status,verbs=get
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes/status,verbs=get
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers/status,verbs=get
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets/status,verbs=get
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims/status,verbs=get
+//+kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete;escalate;bind
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
 
 // PlantReconciler reconciles a Plant object
 type PlantReconciler struct {
@@ -50,10 +68,23 @@ type PlantReconciler struct {
 	Scheme   *runtime.Scheme
 	Workflow workflow.Manager
 	Recorder record.EventRecorder
+
+	// AnalysisAddresses defines the addresses which canary release analysis may query
+	AnalysisAddresses []string
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PlantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// index referenced configuration to find Plants on changes
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &apiv1.Plant{}, configMapRefIndex,
+		func(obj client.Object) []string { return obj.(*apiv1.Plant).ReferencedConfigMaps() }); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &apiv1.Plant{}, secretRefIndex,
+		func(obj client.Object) []string { return obj.(*apiv1.Plant).ReferencedSecrets() }); err != nil {
+		return err
+	}
+
 	bldr := ctrl.NewControllerManagedBy(mgr).
 		For(&apiv1.Plant{}, builder.WithPredicates(
 			notifyWrapper(r.Recorder, predicate.GenerationChangedPredicate{})),
@@ -64,6 +95,13 @@ func (r *PlantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		bldr = bldr.Owns(managedResource, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
 	}
 
+	// add referenced configuration trackers, only metadata is watched to avoid caching the content
+	bldr = bldr.
+		Watches(&source.Kind{Type: &v1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.referencingPlants(configMapRefIndex)),
+			builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &v1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.referencingPlants(secretRefIndex)),
+			builder.OnlyMetadata)
+
 	return bldr.Complete(r)
 }
 
@@ -108,7 +146,10 @@ func (r *PlantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	if err != nil {
 		return r.ErrorHandle(ctx, plant, fmt.Errorf("could not handle Plant control loop: %w", err))
 	}
-	return ctrl.Result{Requeue: requeue}, nil
+	if requeue {
+		return ctrl.Result{Requeue: true}, nil
+	}
+	return ctrl.Result{RequeueAfter: releasePauseRemaining(plant)}, nil
 }
 
 // ErrorHandle logs the error, puts Plant into apiv1.StateError state, and returns rescheduled result.
@@ -148,12 +189,34 @@ func (r *PlantReconciler) StateHandle(ctx context.Context, plant *apiv1.Plant) (
 // Returns true if reconcile should be triggered. Updates Status with observed results.
 func (r *PlantReconciler) HandleProcessingState(ctx context.Context, plant *apiv1.Plant) (bool, error) {
 	// Handle workflow
+	if err := r.PlanRelease(ctx, plant); err != nil {
+		return true, err
+	}
 	execResults, execErr := r.Workflow.WithClient(r.Client).Execute(ctx, plant)
+	releaseChanged := r.AdvanceRelease(ctx, plant, execResults)
+
+	// Handle failed rollouts
+	if rolledBack, err := r.HandleRollback(ctx, plant, execResults); err != nil {
+		execErr = err
+	} else if rolledBack {
+		execErr = nil // rollback in progress, check again on requeue
+	}
+
+	// Handle requested rollbacks
+	if rolledBack, err := r.HandleRollbackTo(ctx, plant, execResults); err != nil {
+		execErr = err
+	} else if rolledBack {
+		execErr = nil // rollback in progress, check again on requeue
+	}
 
 	// Update status (with state) since processing updated it
 	// We ignore the error as it will be self corrected by the requeue
 	uerr := r.UpdateResults(ctx, plant, execResults)
-	return plant.Status.State != apiv1.StateReady || uerr != nil, execErr
+
+	// Requeue immediately to apply release changes, since status updates do not trigger reconciles.
+	// Paused canary steps are checked again once the pause passes.
+	waiting := plant.CanaryActive() && releasePauseRemaining(plant) <= 0
+	return plant.Status.State != apiv1.StateReady || releaseChanged || waiting || uerr != nil, execErr
 }
 
 // HandleDeletingState remove all hanging resources. The garbage collector will
@@ -1,9 +1,32 @@
 package controllers_test
 
 import (
+	"fmt"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"github.com/fhivemind/plant-operator/pkg/utils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 var _ = Describe("Plant with minimal configuration", Ordered, func() {
@@ -43,10 +66,29 @@ var _ = Describe("Plant with advanced configuration", Ordered, func() {
 		Eventually(UNIT_IsPlantValid(plant), Timeout, Interval).Should(BeTrue())
 	})
 
+	It("Should result in a valid state for custom resources", func() {
+		resources := apiv1.ResourcePresets[apiv1.ResourcePresetSmall]
+		plant.Spec.Resources = resources.DeepCopy()
+
+		SyncPlant(plant)
+		Eventually(UNIT_IsPlantValid(plant), Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should result in a valid state for resource preset", func() {
+		preset := apiv1.ResourcePresetMedium
+		plant.Spec.Resources = nil
+		plant.Spec.ResourcePreset = &preset
+
+		SyncPlant(plant)
+		Eventually(UNIT_IsPlantValid(plant), Timeout, Interval).Should(BeTrue())
+	})
+
 	It("Should result in a valid state when custom config removed", func() {
 		plant.Spec.ContainerPort = nil
 		plant.Spec.Replicas = nil
 		plant.Spec.IngressClassName = nil
+		plant.Spec.Resources = nil
+		plant.Spec.ResourcePreset = nil
 
 		SyncPlant(plant)
 		Eventually(UNIT_IsPlantValid(plant), Timeout, Interval).Should(BeTrue())
@@ -83,6 +125,1667 @@ var _ = Describe("Plant with TLS", Ordered, func() {
 	})
 })
 
+var _ = Describe("Plant with environment", Ordered, func() {
+	plant := NewTestPlant("env-plant")
+	RegisterPlant(plant)
+
+	It("Should result in a valid state for env and envFrom", func() {
+		plant.Spec.Env = []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}}
+		plant.Spec.EnvFrom = []corev1.EnvFromSource{{
+			ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"},
+			},
+		}}
+
+		SyncPlant(plant)
+		Eventually(UNIT_IsPlantValid(plant), Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should result in a valid state when env removed", func() {
+		plant.Spec.Env = nil
+		plant.Spec.EnvFrom = nil
+
+		SyncPlant(plant)
+		Eventually(UNIT_IsPlantValid(plant), Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with multiple ports", Ordered, func() {
+	plant := NewTestPlant("ports-plant")
+	RegisterPlant(plant)
+
+	It("Should expose named ports and route ingress paths", func() {
+		plant.Spec.Ports = []apiv1.PlantPort{
+			{Name: "http", ContainerPort: 8080},
+			{Name: "grpc", ContainerPort: 9090},
+			{Name: "admin", ContainerPort: 9901},
+		}
+		plant.Spec.Paths = []apiv1.IngressPath{
+			{Path: "/api", Port: "http"},
+			{Path: "/grpc", Port: "grpc"},
+		}
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			service, err := GetService(plant)
+			if err != nil || len(service.Spec.Ports) != 3 {
+				return false
+			}
+			ingress, err := GetIngress(plant)
+			if err != nil || len(ingress.Spec.Rules) != 1 {
+				return false
+			}
+			paths := ingress.Spec.Rules[0].HTTP.Paths
+			return len(paths) == 2 &&
+				paths[0].Path == "/api" && paths[0].Backend.Service.Port.Number == 8080 &&
+				paths[1].Path == "/grpc" && paths[1].Backend.Service.Port.Number == 9090
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should result in a valid state when ports removed", func() {
+		plant.Spec.Ports = nil
+		plant.Spec.Paths = nil
+
+		SyncPlant(plant)
+		Eventually(UNIT_IsPlantValid(plant), Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			service, err := GetService(plant)
+			if err != nil || len(service.Spec.Ports) != 1 {
+				return false
+			}
+			ingress, err := GetIngress(plant)
+			return err == nil && len(ingress.Spec.Rules) == 1 && len(ingress.Spec.Rules[0].HTTP.Paths) == 1
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with multiple hosts", Ordered, func() {
+	plant := NewTestPlant("hosts-plant")
+	plant.Spec.IngressClassName = utils.Pointer("nginx")
+	RegisterPlant(plant)
+
+	It("Should route and secure all hosts", func() {
+		plant.Spec.AdditionalHosts = []string{"www.example.host"}
+		plant.Spec.TlsCertIssuerRef = &cmmeta.ObjectReference{Name: "custom-issuer"}
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			ingress, err := GetIngress(plant)
+			if err != nil || len(ingress.Spec.Rules) != 2 || len(ingress.Spec.TLS) != 1 {
+				return false
+			}
+			cert, err := GetCertificate(plant)
+			if err != nil {
+				return false
+			}
+			return reflect.DeepEqual(cert.Spec.DNSNames, plant.GetHosts()) &&
+				reflect.DeepEqual(ingress.Spec.TLS[0].Hosts, plant.GetHosts())
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should redirect additional hosts to primary host", func() {
+		plant.Spec.RedirectToHost = true
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			ingress, err := GetIngress(plant)
+			if err != nil || len(ingress.Spec.Rules) != 1 {
+				return false
+			}
+			redirect, err := GetIngressByName(plant.Name+"-redirect", plant.Namespace)
+			return err == nil && len(redirect.Spec.Rules) == 1 &&
+				redirect.Spec.Rules[0].Host == "www.example.host"
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should remove redirect when additional hosts removed", func() {
+		plant.Spec.AdditionalHosts = nil
+		plant.Spec.RedirectToHost = false
+		plant.Spec.TlsCertIssuerRef = nil
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			_, err := GetIngressByName(plant.Name+"-redirect", plant.Namespace)
+			return errors.IsNotFound(err)
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(UNIT_IsPlantValid(plant), Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with Gateway routing", Ordered, func() {
+	plant := NewTestPlant("gateway-plant")
+	RegisterPlant(plant)
+
+	It("Should replace Ingress with HTTPRoute", func() {
+		Eventually(func() error {
+			_, err := GetIngress(plant)
+			return err
+		}, Timeout, Interval).Should(Succeed())
+
+		plant.Spec.Routing = &apiv1.Routing{
+			Mode:    apiv1.RoutingModeGateway,
+			Gateway: &apiv1.GatewayReference{Name: "shared-gateway", Namespace: "gateways", SectionName: "https"},
+		}
+		plant.Spec.AdditionalHosts = []string{"www.example.host"}
+		plant.Spec.RedirectToHost = true
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			_, err := GetIngress(plant)
+			return errors.IsNotFound(err)
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			route, err := GetRoute(plant.Name, plant.Namespace)
+			if err != nil || len(route.Spec.ParentRefs) != 1 || len(route.Spec.Rules) != 1 {
+				return false
+			}
+			parentRef := route.Spec.ParentRefs[0]
+			return parentRef.Name == "shared-gateway" && *parentRef.Namespace == "gateways" && *parentRef.SectionName == "https" &&
+				reflect.DeepEqual(route.Spec.Hostnames, []gatewayv1beta1.Hostname{gatewayv1beta1.Hostname(plant.Spec.Host)}) &&
+				string(route.Spec.Rules[0].BackendRefs[0].Name) == plant.Name
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			redirect, err := GetRoute(plant.Name+"-redirect", plant.Namespace)
+			return err == nil && len(redirect.Spec.Rules) == 1 && len(redirect.Spec.Rules[0].Filters) == 1 &&
+				string(*redirect.Spec.Rules[0].Filters[0].RequestRedirect.Hostname) == plant.Spec.Host
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should be ready once the Gateway accepts the route", func() {
+		Eventually(func() error {
+			route, err := GetRoute(plant.Name, plant.Namespace)
+			if err != nil {
+				return err
+			}
+			parentRef := route.Spec.ParentRefs[0]
+			route.Status.Parents = []gatewayv1beta1.RouteParentStatus{{
+				ParentRef: gatewayv1beta1.ParentReference{
+					Name: parentRef.Name, Namespace: parentRef.Namespace, SectionName: parentRef.SectionName,
+				},
+				ControllerName: "example.com/gateway-controller",
+				Conditions: []v1.Condition{
+					{Type: "Accepted", Status: v1.ConditionTrue, Reason: "Accepted", ObservedGeneration: route.Generation, LastTransitionTime: v1.Now()},
+					{Type: "ResolvedRefs", Status: v1.ConditionTrue, Reason: "ResolvedRefs", ObservedGeneration: route.Generation, LastTransitionTime: v1.Now()},
+				},
+			}}
+			return PlantClient.Status().Update(Ctx, route)
+		}, Timeout, Interval).Should(Succeed())
+
+		Eventually(func() bool {
+			freshPlant, err := GetPlant(plant.Name, plant.Namespace)
+			if err != nil {
+				return false
+			}
+			condition := meta.FindStatusCondition(freshPlant.Status.Conditions, string(apiv1.ConditionTypeAvailableFor("HTTPRoute")))
+			return condition != nil && condition.Status == v1.ConditionTrue
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should remove HTTPRoute when switching back to Ingress", func() {
+		plant.Spec.Routing = nil
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			_, err := GetRoute(plant.Name, plant.Namespace)
+			_, redirectErr := GetRoute(plant.Name+"-redirect", plant.Namespace)
+			return errors.IsNotFound(err) && errors.IsNotFound(redirectErr)
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() error {
+			_, err := GetIngress(plant)
+			return err
+		}, Timeout, Interval).Should(Succeed())
+	})
+})
+
+var _ = Describe("Plant with routing matches", Ordered, func() {
+	plant := NewTestPlant("matches-plant")
+	plant.Spec.IngressClassName = utils.Pointer("nginx")
+	plant.Spec.Routing = &apiv1.Routing{
+		Matches:      []apiv1.RouteMatch{{Type: apiv1.RouteMatchHeader, Name: "X-Variant", Value: "b"}},
+		VariantImage: "nginx:1.23",
+	}
+	RegisterPlant(plant)
+
+	variantName := plant.Name + "-variant"
+
+	It("Should route matched requests to variant Deployment with Ingress", func() {
+		Eventually(func() bool {
+			variant, err := GetDeploymentByName(variantName, plant.Namespace)
+			if err != nil || variant.Spec.Template.Spec.Containers[0].Image != "nginx:1.23" {
+				return false
+			}
+			stable, err := GetDeployment(plant)
+			return err == nil && stable.Spec.Template.Spec.Containers[0].Image == plant.Spec.Image
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			service, err := GetServiceByName(variantName, plant.Namespace)
+			return err == nil && service.Spec.Selector[apiv1.VariantOfLabel] == plant.Name
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			ingress, err := GetIngressByName(variantName, plant.Namespace)
+			return err == nil && ingress.Annotations["nginx.ingress.kubernetes.io/canary"] == "true" &&
+				ingress.Annotations["nginx.ingress.kubernetes.io/canary-by-header"] == "X-Variant" &&
+				ingress.Annotations["nginx.ingress.kubernetes.io/canary-by-header-value"] == "b" &&
+				ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name == variantName
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should route matched requests to variant Deployment with HTTPRoute", func() {
+		plant.Spec.Routing.Mode = apiv1.RoutingModeGateway
+		plant.Spec.Routing.Gateway = &apiv1.GatewayReference{Name: "shared-gateway"}
+		plant.Spec.Routing.Matches = append(plant.Spec.Routing.Matches,
+			apiv1.RouteMatch{Type: apiv1.RouteMatchCookie, Name: "variant", Value: "b"},
+			apiv1.RouteMatch{Type: apiv1.RouteMatchQueryParam, Name: "variant", Value: "b"},
+		)
+		SyncPlant(plant)
+
+		Eventually(func() bool {
+			_, err := GetIngressByName(variantName, plant.Namespace)
+			return errors.IsNotFound(err)
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			route, err := GetRoute(plant.Name, plant.Namespace)
+			if err != nil || len(route.Spec.Rules) != 2 || len(route.Spec.Rules[0].Matches) != 3 {
+				return false
+			}
+			variantRule, stableRule := route.Spec.Rules[0], route.Spec.Rules[1]
+			return string(variantRule.BackendRefs[0].Name) == variantName && string(stableRule.BackendRefs[0].Name) == plant.Name &&
+				variantRule.Matches[0].Headers[0].Name == "X-Variant" && variantRule.Matches[0].Headers[0].Value == "b" &&
+				variantRule.Matches[1].Headers[0].Name == "Cookie" &&
+				*variantRule.Matches[1].Headers[0].Type == gatewayv1beta1.HeaderMatchRegularExpression &&
+				variantRule.Matches[2].QueryParams[0].Name == "variant"
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should remove variant Deployment when matches are removed", func() {
+		plant.Spec.Routing = nil
+		SyncPlant(plant)
+
+		Eventually(func() bool {
+			_, deploymentErr := GetDeploymentByName(variantName, plant.Namespace)
+			_, serviceErr := GetServiceByName(variantName, plant.Namespace)
+			return errors.IsNotFound(deploymentErr) && errors.IsNotFound(serviceErr)
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with ingress options", Ordered, func() {
+	plant := NewTestPlant("ingress-options-plant")
+	plant.Spec.IngressClassName = utils.Pointer("nginx")
+	maxBodySize := resource.MustParse("10Mi")
+	plant.Spec.IngressOptions = &apiv1.IngressOptions{
+		MaxBodySize:     &maxBodySize,
+		CORS:            &apiv1.IngressCORS{AllowOrigins: []string{"https://example.com"}},
+		BackendProtocol: apiv1.BackendProtocolGRPC,
+	}
+	RegisterPlant(plant)
+
+	It("Should translate options to Ingress annotations", func() {
+		Eventually(func() bool {
+			ingress, err := GetIngress(plant)
+			return err == nil && ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"] == "10485760" &&
+				ingress.Annotations["nginx.ingress.kubernetes.io/cors-allow-origin"] == "https://example.com" &&
+				ingress.Annotations["nginx.ingress.kubernetes.io/backend-protocol"] == "GRPC"
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should remove annotations of removed options and keep other annotations", func() {
+		Eventually(func() error {
+			ingress, err := GetIngress(plant)
+			if err != nil {
+				return err
+			}
+			ingress.Annotations["example.com/team"] = "plants"
+			return PlantClient.Update(Ctx, ingress)
+		}, Timeout, Interval).Should(Succeed())
+
+		plant.Spec.IngressOptions.CORS = nil
+		SyncPlant(plant)
+		Eventually(func() bool {
+			ingress, err := GetIngress(plant)
+			if err != nil {
+				return false
+			}
+			_, corsFound := ingress.Annotations["nginx.ingress.kubernetes.io/enable-cors"]
+			return !corsFound && ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"] == "10485760" &&
+				ingress.Annotations["example.com/team"] == "plants"
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should translate options for the Ingress class", func() {
+		className := "traefik"
+		plant.Spec.IngressClassName = &className
+		plant.Spec.IngressOptions = &apiv1.IngressOptions{BackendProtocol: apiv1.BackendProtocolGRPC}
+		SyncPlant(plant)
+
+		Eventually(func() bool {
+			service, err := GetService(plant)
+			return err == nil && service.Annotations["traefik.ingress.kubernetes.io/service.serversscheme"] == "h2c"
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			ingress, err := GetIngress(plant)
+			if err != nil {
+				return false
+			}
+			_, protocolFound := ingress.Annotations["nginx.ingress.kubernetes.io/backend-protocol"]
+			return !protocolFound && *ingress.Spec.IngressClassName == className
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with custom service", Ordered, func() {
+	plant := NewTestPlant("service-plant")
+	RegisterPlant(plant)
+
+	var clusterIP string
+	It("Should default to NodePort service type", func() {
+		Eventually(func() bool {
+			service, err := GetService(plant)
+			if err != nil {
+				return false
+			}
+			clusterIP = service.Spec.ClusterIP
+			return service.Spec.Type == corev1.ServiceTypeNodePort && service.Spec.Ports[0].NodePort != 0
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should clear node ports and preserve cluster IP when switching to ClusterIP", func() {
+		plant.Spec.Service = &apiv1.ServiceConfig{
+			Type:        corev1.ServiceTypeClusterIP,
+			Annotations: map[string]string{"example.com/team": "plants"},
+		}
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			service, err := GetService(plant)
+			if err != nil {
+				return false
+			}
+			return service.Spec.Type == corev1.ServiceTypeClusterIP &&
+				service.Spec.Ports[0].NodePort == 0 &&
+				service.Spec.ClusterIP == clusterIP &&
+				service.Annotations["example.com/team"] == "plants"
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(UNIT_IsPlantValid(plant), Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should remove annotations and not wait for load balancer address", func() {
+		plant.Spec.Service = &apiv1.ServiceConfig{Type: corev1.ServiceTypeLoadBalancer}
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			service, err := GetService(plant)
+			if err != nil {
+				return false
+			}
+			_, annotated := service.Annotations["example.com/team"]
+			return service.Spec.Type == corev1.ServiceTypeLoadBalancer && !annotated
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			freshPlant, err := GetPlant(plant.Name, plant.Namespace)
+			if err != nil {
+				return false
+			}
+			condition := meta.FindStatusCondition(freshPlant.Status.Conditions, string(apiv1.ConditionTypeAvailableFor("Service")))
+			return condition != nil && condition.Status == v1.ConditionTrue
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with probes", Ordered, func() {
+	plant := NewTestPlant("probes-plant")
+	RegisterPlant(plant)
+
+	It("Should render probes targeting container port", func() {
+		plant.Spec.Probes = &apiv1.Probes{
+			Readiness: &apiv1.Probe{HTTPGet: &apiv1.HTTPGetProbe{Path: "/healthz"}},
+			Liveness:  &apiv1.Probe{},
+		}
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return false
+			}
+			container := deployment.Spec.Template.Spec.Containers[0]
+			return container.ReadinessProbe != nil &&
+				container.ReadinessProbe.HTTPGet.Path == "/healthz" &&
+				container.ReadinessProbe.HTTPGet.Port.IntValue() == int(GetPlantPort(plant)) &&
+				container.LivenessProbe != nil &&
+				container.LivenessProbe.TCPSocket.Port.IntValue() == int(GetPlantPort(plant))
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should remove probes when removed from Plant", func() {
+		plant.Spec.Probes = nil
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return false
+			}
+			container := deployment.Spec.Template.Spec.Containers[0]
+			return container.ReadinessProbe == nil && container.LivenessProbe == nil
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with autoscaling", Ordered, func() {
+	plant := NewTestPlant("autoscaling-plant")
+	RegisterPlant(plant)
+
+	It("Should create autoscaler and stop enforcing replicas", func() {
+		plant.Spec.Resources = &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+		}
+		plant.Spec.Autoscaling = &apiv1.Autoscaling{
+			MinReplicas:                    new(int32),
+			MaxReplicas:                    4,
+			TargetCPUUtilizationPercentage: new(int32),
+		}
+		*plant.Spec.Autoscaling.MinReplicas = 2
+		*plant.Spec.Autoscaling.TargetCPUUtilizationPercentage = 70
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			autoscaler, err := GetAutoscaler(plant)
+			return err == nil && *autoscaler.Spec.MinReplicas == 2 && autoscaler.Spec.MaxReplicas == 4 &&
+				len(autoscaler.Spec.Metrics) == 1 && *autoscaler.Spec.Metrics[0].Resource.Target.AverageUtilization == 70
+		}, Timeout, Interval).Should(BeTrue())
+
+		// simulate autoscaler scaling the Deployment
+		Eventually(func() error {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return err
+			}
+			*deployment.Spec.Replicas = 3
+			return PlantClient.Update(Ctx, deployment)
+		}, Timeout, Interval).Should(Succeed())
+		Consistently(func() bool {
+			deployment, err := GetDeployment(plant)
+			return err == nil && *deployment.Spec.Replicas == 3
+		}, 2*time.Second, Interval).Should(BeTrue())
+	})
+
+	It("Should remove autoscaler and enforce replicas when autoscaling disabled", func() {
+		plant.Spec.Autoscaling = nil
+		plant.Spec.Resources = nil
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			_, err := GetAutoscaler(plant)
+			return errors.IsNotFound(err)
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			return err == nil && *deployment.Spec.Replicas == apiv1.DefaultReplicaCount
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(UNIT_IsPlantValid(plant), Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with disruption budget", Ordered, func() {
+	plant := NewTestPlant("budget-plant")
+	RegisterPlant(plant)
+
+	It("Should not create budget for a single replica", func() {
+		Eventually(UNIT_IsPlantValid(plant), Timeout, Interval).Should(BeTrue())
+		_, err := GetDisruptionBudget(plant)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should create default budget for multiple replicas", func() {
+		plant.Spec.Replicas = new(int32)
+		*plant.Spec.Replicas = 3
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			budget, err := GetDisruptionBudget(plant)
+			return err == nil && budget.Spec.MinAvailable == nil &&
+				budget.Spec.MaxUnavailable != nil && budget.Spec.MaxUnavailable.IntValue() == 1
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should use custom budget", func() {
+		minAvailable := intstr.FromString("50%")
+		plant.Spec.DisruptionBudget = &apiv1.DisruptionBudget{MinAvailable: &minAvailable}
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			budget, err := GetDisruptionBudget(plant)
+			return err == nil && budget.Spec.MaxUnavailable == nil &&
+				reflect.DeepEqual(budget.Spec.MinAvailable, &minAvailable)
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should remove budget when no longer required", func() {
+		plant.Spec.Replicas = nil
+		plant.Spec.DisruptionBudget = nil
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			_, err := GetDisruptionBudget(plant)
+			return errors.IsNotFound(err)
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with scale subresource", Ordered, func() {
+	plant := NewTestPlant("scale-plant")
+	RegisterPlant(plant)
+
+	It("Should report selector and URL in status", func() {
+		Eventually(func() bool {
+			freshPlant, err := GetPlant(plant.Name, plant.Namespace)
+			return err == nil && freshPlant.Status.URL == "http://"+plant.Spec.Host &&
+				freshPlant.Status.Selector != ""
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should scale Deployment through scale subresource", func() {
+		scale := &autoscalingv1.Scale{}
+		Expect(PlantClient.SubResource("scale").Get(Ctx, plant, scale)).To(Succeed())
+		scale.Spec.Replicas = 2
+		Expect(PlantClient.SubResource("scale").Update(Ctx, plant, client.WithSubResourceBody(scale))).To(Succeed())
+
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			return err == nil && *deployment.Spec.Replicas == 2
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with volumes", Ordered, func() {
+	plant := NewTestPlant("volumes-plant")
+	RegisterPlant(plant)
+
+	It("Should mount volumes and create managed claim", func() {
+		plant.Spec.Volumes = []apiv1.PlantVolume{
+			{Name: "cache", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			{Name: "data", ManagedClaim: &apiv1.ManagedClaim{Size: resource.MustParse("1Gi")}},
+		}
+		plant.Spec.VolumeMounts = []corev1.VolumeMount{
+			{Name: "cache", MountPath: "/cache"},
+			{Name: "data", MountPath: "/data"},
+		}
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return false
+			}
+			podSpec := deployment.Spec.Template.Spec
+			return len(podSpec.Volumes) == 2 && len(podSpec.Containers[0].VolumeMounts) == 2 &&
+				podSpec.Volumes[1].PersistentVolumeClaim != nil &&
+				podSpec.Volumes[1].PersistentVolumeClaim.ClaimName == plant.Name
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			claim, err := GetClaim(plant)
+			return err == nil && v1.IsControlledBy(claim, plant)
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should release managed claim when retained", func() {
+		plant.Spec.Volumes[1].ManagedClaim.RetainOnDelete = true
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			claim, err := GetClaim(plant)
+			return err == nil && !v1.IsControlledBy(claim, plant)
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should remove volumes when removed from Plant", func() {
+		plant.Spec.Volumes = nil
+		plant.Spec.VolumeMounts = nil
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			return err == nil && len(deployment.Spec.Template.Spec.Volumes) == 0
+		}, Timeout, Interval).Should(BeTrue())
+
+		// retained claim is not removed
+		Consistently(func() error {
+			_, err := GetClaim(plant)
+			return err
+		}, 2*time.Second, Interval).Should(Succeed())
+	})
+})
+
+var _ = Describe("Plant with late binding claim", Ordered, func() {
+	plant := NewTestPlant("binding-plant")
+	bindingMode := storagev1.VolumeBindingWaitForFirstConsumer
+	storageClass := &storagev1.StorageClass{
+		ObjectMeta:        v1.ObjectMeta{Name: plant.Name},
+		Provisioner:       "example.com/provisioner",
+		VolumeBindingMode: &bindingMode,
+	}
+	plant.Spec.Volumes = []apiv1.PlantVolume{{Name: "data", ManagedClaim: &apiv1.ManagedClaim{
+		Size:             resource.MustParse("1Gi"),
+		StorageClassName: &storageClass.Name,
+	}}}
+
+	BeforeAll(func() {
+		Expect(PlantClient.Create(Ctx, storageClass)).NotTo(HaveOccurred())
+		DeferCleanup(func() { Expect(PlantClient.Delete(Ctx, storageClass)).NotTo(HaveOccurred()) })
+	})
+	RegisterPlant(plant)
+
+	It("Should report pending claim as available", func() {
+		Eventually(func() bool {
+			freshPlant, err := GetPlant(plant.Name, plant.Namespace)
+			if err != nil {
+				return false
+			}
+			condition := meta.FindStatusCondition(freshPlant.Status.Conditions, string(apiv1.ConditionTypeAvailableFor("PersistentVolumeClaim")))
+			return condition != nil && condition.Status == v1.ConditionTrue
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with additional containers", Ordered, func() {
+	plant := NewTestPlant("containers-plant")
+	RegisterPlant(plant)
+
+	It("Should render init and sidecar containers", func() {
+		plant.Spec.InitContainers = []corev1.Container{
+			{Name: "migrate", Image: "busybox:1.36", Command: []string{"true"}},
+		}
+		plant.Spec.Sidecars = []corev1.Container{{
+			Name:  "proxy",
+			Image: "envoyproxy/envoy:v1.25.0",
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("0.5")},
+			},
+			ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Port: intstr.FromInt(9901)},
+			}},
+		}}
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return false
+			}
+			podSpec := deployment.Spec.Template.Spec
+			return len(podSpec.InitContainers) == 1 && podSpec.InitContainers[0].Name == "migrate" &&
+				len(podSpec.Containers) == 2 && podSpec.Containers[0].Name == plant.Name &&
+				podSpec.Containers[1].Name == "proxy"
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should not update Deployment without changes", func() {
+		deployment, err := GetDeployment(plant)
+		Expect(err).NotTo(HaveOccurred())
+		Consistently(func() int64 {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return 0
+			}
+			return deployment.Generation
+		}, 2*time.Second, Interval).Should(Equal(deployment.Generation))
+	})
+
+	It("Should update containers when fields are removed", func() {
+		plant.Spec.InitContainers[0].Command = nil
+		plant.Spec.Sidecars[0].ReadinessProbe = nil
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			return err == nil && len(deployment.Spec.Template.Spec.InitContainers[0].Command) == 0 &&
+				deployment.Spec.Template.Spec.Containers[1].ReadinessProbe == nil
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should remove containers when removed from Plant", func() {
+		plant.Spec.InitContainers = nil
+		plant.Spec.Sidecars = nil
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			return err == nil && len(deployment.Spec.Template.Spec.InitContainers) == 0 &&
+				len(deployment.Spec.Template.Spec.Containers) == 1
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with entrypoint overrides", Ordered, func() {
+	plant := NewTestPlant("entrypoint-plant")
+	RegisterPlant(plant)
+
+	It("Should render command, args and lifecycle", func() {
+		plant.Spec.Command = []string{"/bin/worker"}
+		plant.Spec.Args = []string{"--queue", "default"}
+		plant.Spec.WorkingDir = "/app"
+		plant.Spec.Lifecycle = &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"sleep", "5"}}},
+		}
+		plant.Spec.TerminationGracePeriodSeconds = new(int64)
+		*plant.Spec.TerminationGracePeriodSeconds = 60
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return false
+			}
+			podSpec := deployment.Spec.Template.Spec
+			container := podSpec.Containers[0]
+			return reflect.DeepEqual(container.Command, plant.Spec.Command) &&
+				reflect.DeepEqual(container.Args, plant.Spec.Args) &&
+				container.WorkingDir == "/app" &&
+				container.Lifecycle != nil && container.Lifecycle.PreStop != nil &&
+				*podSpec.TerminationGracePeriodSeconds == 60
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should restore image defaults when overrides removed", func() {
+		plant.Spec.Command = nil
+		plant.Spec.Args = nil
+		plant.Spec.WorkingDir = ""
+		plant.Spec.Lifecycle = nil
+		plant.Spec.TerminationGracePeriodSeconds = nil
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return false
+			}
+			podSpec := deployment.Spec.Template.Spec
+			container := podSpec.Containers[0]
+			return len(container.Command) == 0 && len(container.Args) == 0 && container.WorkingDir == "" &&
+				container.Lifecycle == nil && *podSpec.TerminationGracePeriodSeconds == corev1.DefaultTerminationGracePeriodSeconds
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with service account", Ordered, func() {
+	plant := NewTestPlant("account-plant")
+	role := &rbacv1.Role{
+		ObjectMeta: v1.ObjectMeta{Name: plant.Name + "-reader", Namespace: plant.Namespace},
+		Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "list"}},
+		},
+	}
+
+	BeforeAll(func() {
+		Expect(PlantClient.Create(Ctx, role)).NotTo(HaveOccurred())
+	})
+	RegisterPlant(plant)
+
+	It("Should create service account bound to referenced role", func() {
+		plant.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "registry-credentials"}}
+		plant.Spec.ServiceAccount = &apiv1.ServiceAccountConfig{
+			Create:      true,
+			Annotations: map[string]string{"example.com/role": "reader"},
+			RoleRef:     &apiv1.ServiceAccountRoleRef{Kind: "Role", Name: role.Name},
+		}
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return false
+			}
+			podSpec := deployment.Spec.Template.Spec
+			return podSpec.ServiceAccountName == plant.Name &&
+				reflect.DeepEqual(podSpec.ImagePullSecrets, plant.Spec.ImagePullSecrets)
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			account := &corev1.ServiceAccount{}
+			binding := &rbacv1.RoleBinding{}
+			key := client.ObjectKey{Name: plant.Name, Namespace: plant.Namespace}
+			return PlantClient.Get(Ctx, key, account) == nil &&
+				account.Annotations["example.com/role"] == "reader" &&
+				PlantClient.Get(Ctx, key, binding) == nil && binding.Subjects[0].Name == plant.Name &&
+				binding.RoleRef.Kind == "Role" && binding.RoleRef.Name == role.Name
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should remove annotations and rebind to referenced cluster role", func() {
+		plant.Spec.ServiceAccount.Annotations = nil
+		plant.Spec.ServiceAccount.RoleRef = &apiv1.ServiceAccountRoleRef{Kind: "ClusterRole", Name: "view"}
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			account := &corev1.ServiceAccount{}
+			binding := &rbacv1.RoleBinding{}
+			key := client.ObjectKey{Name: plant.Name, Namespace: plant.Namespace}
+			if PlantClient.Get(Ctx, key, account) != nil || PlantClient.Get(Ctx, key, binding) != nil {
+				return false
+			}
+			_, annotated := account.Annotations["example.com/role"]
+			return !annotated && binding.RoleRef.Kind == "ClusterRole" && binding.RoleRef.Name == "view"
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should grant declared rules through managed role", func() {
+		plant.Spec.ServiceAccount.RoleRef = nil
+		plant.Spec.ServiceAccount.Rules = []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}},
+		}
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			managedRole := &rbacv1.Role{}
+			binding := &rbacv1.RoleBinding{}
+			key := client.ObjectKey{Name: plant.Name, Namespace: plant.Namespace}
+			if PlantClient.Get(Ctx, key, managedRole) != nil || PlantClient.Get(Ctx, key, binding) != nil {
+				return false
+			}
+			return reflect.DeepEqual(managedRole.Rules, plant.Spec.ServiceAccount.Rules) &&
+				binding.RoleRef.Kind == "Role" && binding.RoleRef.Name == plant.Name
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should remove renamed service account", func() {
+		plant.Spec.ServiceAccount.Name = plant.Name + "-renamed"
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			binding := &rbacv1.RoleBinding{}
+			key := client.ObjectKey{Name: plant.Name, Namespace: plant.Namespace}
+			renamedKey := client.ObjectKey{Name: plant.Spec.ServiceAccount.Name, Namespace: plant.Namespace}
+			return errors.IsNotFound(PlantClient.Get(Ctx, key, &corev1.ServiceAccount{})) &&
+				PlantClient.Get(Ctx, renamedKey, &corev1.ServiceAccount{}) == nil &&
+				PlantClient.Get(Ctx, key, binding) == nil && binding.Subjects[0].Name == renamedKey.Name
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should remove managed identity when removed from Plant", func() {
+		renamedKey := client.ObjectKey{Name: plant.Spec.ServiceAccount.Name, Namespace: plant.Namespace}
+		plant.Spec.ImagePullSecrets = nil
+		plant.Spec.ServiceAccount = nil
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			key := client.ObjectKey{Name: plant.Name, Namespace: plant.Namespace}
+			return errors.IsNotFound(PlantClient.Get(Ctx, renamedKey, &corev1.ServiceAccount{})) &&
+				errors.IsNotFound(PlantClient.Get(Ctx, key, &rbacv1.RoleBinding{})) &&
+				errors.IsNotFound(PlantClient.Get(Ctx, key, &rbacv1.Role{})) &&
+				PlantClient.Get(Ctx, client.ObjectKeyFromObject(role), &rbacv1.Role{}) == nil
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			return err == nil && deployment.Spec.Template.Spec.ServiceAccountName == "" &&
+				len(deployment.Spec.Template.Spec.ImagePullSecrets) == 0
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with security profile", Ordered, func() {
+	plant := NewTestPlant("security-plant")
+	RegisterPlant(plant)
+
+	It("Should render restricted security contexts", func() {
+		profile := apiv1.SecurityProfileRestricted
+		plant.Spec.SecurityProfile = &profile
+		plant.Spec.Sidecars = []corev1.Container{{Name: "proxy", Image: "envoyproxy/envoy:v1.25.0"}}
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return false
+			}
+			podSpec := deployment.Spec.Template.Spec
+			for _, container := range podSpec.Containers {
+				context := container.SecurityContext
+				if context == nil || context.AllowPrivilegeEscalation == nil || *context.AllowPrivilegeEscalation {
+					return false
+				}
+			}
+			return podSpec.SecurityContext != nil && podSpec.SecurityContext.RunAsNonRoot != nil &&
+				*podSpec.SecurityContext.RunAsNonRoot
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should remove security contexts when profile removed", func() {
+		plant.Spec.SecurityProfile = nil
+		plant.Spec.Sidecars = nil
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return false
+			}
+			podSpec := deployment.Spec.Template.Spec
+			return podSpec.Containers[0].SecurityContext == nil &&
+				equality.Semantic.DeepEqual(podSpec.SecurityContext, &corev1.PodSecurityContext{})
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with scheduling controls", Ordered, func() {
+	plant := NewTestPlant("scheduling-plant")
+	RegisterPlant(plant)
+
+	It("Should render scheduling constraints and high availability", func() {
+		plant.Spec.NodeSelector = map[string]string{"kubernetes.io/os": "linux"}
+		plant.Spec.Tolerations = []corev1.Toleration{
+			{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "web", Effect: corev1.TaintEffectNoSchedule},
+		}
+		plant.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+			{MaxSkew: 1, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.ScheduleAnyway},
+		}
+		plant.Spec.HighAvailability = true
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return false
+			}
+			podSpec := deployment.Spec.Template.Spec
+			return reflect.DeepEqual(podSpec.NodeSelector, plant.Spec.NodeSelector) &&
+				len(podSpec.Tolerations) == 1 &&
+				len(podSpec.TopologySpreadConstraints) == 1 &&
+				podSpec.TopologySpreadConstraints[0].LabelSelector != nil &&
+				podSpec.Affinity != nil && podSpec.Affinity.PodAntiAffinity != nil &&
+				len(podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution) == 2
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should remove scheduling constraints when removed from Plant", func() {
+		plant.Spec.NodeSelector = nil
+		plant.Spec.Tolerations = nil
+		plant.Spec.TopologySpreadConstraints = nil
+		plant.Spec.HighAvailability = false
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return false
+			}
+			podSpec := deployment.Spec.Template.Spec
+			return len(podSpec.NodeSelector) == 0 && len(podSpec.Tolerations) == 0 &&
+				len(podSpec.TopologySpreadConstraints) == 0 && podSpec.Affinity == nil
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with rollout strategy", Ordered, func() {
+	plant := NewTestPlant("strategy-plant")
+	RegisterPlant(plant)
+
+	It("Should render Recreate strategy and progress deadline", func() {
+		plant.Spec.Strategy = &apiv1.RolloutStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+		plant.Spec.ProgressDeadlineSeconds = new(int32)
+		*plant.Spec.ProgressDeadlineSeconds = 120
+
+		SyncPlant(plant)
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			return err == nil && deployment.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType &&
+				deployment.Spec.Strategy.RollingUpdate == nil && *deployment.Spec.ProgressDeadlineSeconds == 120
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should report stuck rollout as error", func() {
+		Eventually(func() error {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return err
+			}
+			deployment.Status.Conditions = []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: "ReplicaSet has timed out progressing.",
+			}}
+			deployment.Status.ObservedGeneration = deployment.Generation
+			return PlantClient.Status().Update(Ctx, deployment)
+		}, Timeout, Interval).Should(Succeed())
+
+		Eventually(func() bool {
+			freshPlant, err := GetPlant(plant.Name, plant.Namespace)
+			if err != nil {
+				return false
+			}
+			condition := meta.FindStatusCondition(freshPlant.Status.Conditions, string(apiv1.ConditionTypeAvailableFor("Deployment")))
+			return freshPlant.Status.State == apiv1.StateError && condition != nil &&
+				strings.Contains(condition.Message, "progress deadline")
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with rollout in progress", Ordered, func() {
+	plant := NewTestPlant("rollout-plant")
+	RegisterPlant(plant)
+
+	deploymentCondition := func() *v1.Condition {
+		freshPlant, err := GetPlant(plant.Name, plant.Namespace)
+		if err != nil {
+			return nil
+		}
+		return meta.FindStatusCondition(freshPlant.Status.Conditions, string(apiv1.ConditionTypeAvailableFor("Deployment")))
+	}
+	setDeploymentStatus := func(status appsv1.DeploymentStatus) func() error {
+		return func() error {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return err
+			}
+			status.ObservedGeneration = deployment.Generation
+			deployment.Status = status
+			return PlantClient.Status().Update(Ctx, deployment)
+		}
+	}
+
+	It("Should report rollout progress while old pods are terminating", func() {
+		Eventually(setDeploymentStatus(appsv1.DeploymentStatus{
+			Replicas: 2, UpdatedReplicas: 1, ReadyReplicas: 2, AvailableReplicas: 1,
+		}), Timeout, Interval).Should(Succeed())
+
+		Eventually(func() bool {
+			condition := deploymentCondition()
+			return condition != nil && condition.Status == v1.ConditionFalse &&
+				strings.Contains(condition.Message, "1 old replicas are pending termination")
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should be ready when rollout completes", func() {
+		Eventually(setDeploymentStatus(appsv1.DeploymentStatus{
+			Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1,
+		}), Timeout, Interval).Should(Succeed())
+
+		Eventually(func() bool {
+			condition := deploymentCondition()
+			return condition != nil && condition.Status == v1.ConditionTrue
+		}, Timeout, Interval).Should(BeTrue())
+	})
+	It("Should wait until rollout spec update is observed", func() {
+		Eventually(func() error {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return err
+			}
+			deployment.Status = appsv1.DeploymentStatus{
+				ObservedGeneration: deployment.Generation - 1,
+				Replicas:           1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1,
+			}
+			return PlantClient.Status().Update(Ctx, deployment)
+		}, Timeout, Interval).Should(Succeed())
+
+		Eventually(func() bool {
+			condition := deploymentCondition()
+			return condition != nil && condition.Status == v1.ConditionFalse &&
+				strings.Contains(condition.Message, "waiting for rollout spec update to be observed")
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with automatic rollback", Ordered, func() {
+	plant := NewTestPlant("rollback-plant")
+	plant.Spec.Rollback = &apiv1.RollbackPolicy{Enabled: true}
+	RegisterPlant(plant)
+
+	const revisionAnnotation = "deployment.kubernetes.io/revision"
+	var readyImage string
+
+	It("Should record last ready revision", func() {
+		Eventually(func() error {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return err
+			}
+			readyImage = deployment.Spec.Template.Spec.Containers[0].Image
+
+			// Create ReplicaSet of the ready revision
+			template := deployment.Spec.Template.DeepCopy()
+			template.Labels["pod-template-hash"] = "ready"
+			replicaSet := &appsv1.ReplicaSet{
+				ObjectMeta: v1.ObjectMeta{
+					Name:        plant.Name + "-ready",
+					Namespace:   plant.Namespace,
+					Labels:      template.Labels,
+					Annotations: map[string]string{revisionAnnotation: "1"},
+					OwnerReferences: []v1.OwnerReference{
+						*v1.NewControllerRef(deployment, appsv1.SchemeGroupVersion.WithKind("Deployment")),
+					},
+				},
+				Spec: appsv1.ReplicaSetSpec{
+					Selector: &v1.LabelSelector{MatchLabels: template.Labels},
+					Template: *template,
+				},
+			}
+			if err := PlantClient.Create(Ctx, replicaSet); err != nil && !errors.IsAlreadyExists(err) {
+				return err
+			}
+
+			// Mark Deployment ready at that revision
+			if deployment.Annotations == nil {
+				deployment.Annotations = make(map[string]string)
+			}
+			deployment.Annotations[revisionAnnotation] = "1"
+			if err := PlantClient.Update(Ctx, deployment); err != nil {
+				return err
+			}
+			deployment.Status = appsv1.DeploymentStatus{
+				ObservedGeneration: deployment.Generation,
+				Replicas:           1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1,
+			}
+			return PlantClient.Status().Update(Ctx, deployment)
+		}, Timeout, Interval).Should(Succeed())
+
+		Eventually(func() string {
+			freshPlant, err := GetPlant(plant.Name, plant.Namespace)
+			if err != nil {
+				return ""
+			}
+			return freshPlant.Status.LastReadyRevision
+		}, Timeout, Interval).Should(Equal("1"))
+	})
+
+	It("Should roll back when new image fails to roll out", func() {
+		plant.Spec.Image = "nginx:broken"
+		SyncPlant(plant)
+
+		Eventually(func() error {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return err
+			}
+			if deployment.Spec.Template.Spec.Containers[0].Image != plant.Spec.Image {
+				return fmt.Errorf("image not updated")
+			}
+			deployment.Status.Conditions = []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: "ReplicaSet has timed out progressing.",
+			}}
+			deployment.Status.ObservedGeneration = deployment.Generation
+			return PlantClient.Status().Update(Ctx, deployment)
+		}, Timeout, Interval).Should(Succeed())
+
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			return err == nil && deployment.Spec.Template.Spec.Containers[0].Image == readyImage
+		}, Timeout, Interval).Should(BeTrue())
+
+		Eventually(func() bool {
+			freshPlant, err := GetPlant(plant.Name, plant.Namespace)
+			return err == nil && freshPlant.Status.Rollback != nil &&
+				freshPlant.Status.Rollback.FailedImage == "nginx:broken" && freshPlant.Status.Rollback.Revision == "1"
+		}, Timeout, Interval).Should(BeTrue())
+	})
+	It("Should keep rolled back revision when other fields change", func() {
+		plant.Spec.Replicas = new(int32)
+		*plant.Spec.Replicas = 2
+		SyncPlant(plant)
+
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			return err == nil && *deployment.Spec.Replicas == 2 &&
+				deployment.Spec.Template.Spec.Containers[0].Image == readyImage
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should apply pod template changes and clear rollback", func() {
+		plant.Spec.Env = []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}}
+		SyncPlant(plant)
+
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return false
+			}
+			container := deployment.Spec.Template.Spec.Containers[0]
+			return container.Image == plant.Spec.Image && reflect.DeepEqual(container.Env, plant.Spec.Env)
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			freshPlant, err := GetPlant(plant.Name, plant.Namespace)
+			return err == nil && freshPlant.Status.Rollback == nil
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with revision history", Ordered, func() {
+	plant := NewTestPlant("history-plant")
+	initialImage := plant.Spec.Image
+	RegisterPlant(plant)
+
+	history := func() []apiv1.PlantRevision {
+		freshPlant, err := GetPlant(plant.Name, plant.Namespace)
+		if err != nil {
+			return nil
+		}
+		return freshPlant.Status.History
+	}
+	deploymentImage := func() string {
+		deployment, err := GetDeployment(plant)
+		if err != nil {
+			return ""
+		}
+		return deployment.Spec.Template.Spec.Containers[0].Image
+	}
+
+	// observeRevision creates the ReplicaSet of the current Deployment pod template
+	// and marks the Deployment ready at the given revision
+	observeRevision := func(revision string) func() error {
+		return func() error {
+			deployment, err := GetDeployment(plant)
+			if err != nil {
+				return err
+			}
+			template := deployment.Spec.Template.DeepCopy()
+			template.Labels["pod-template-hash"] = "revision-" + revision
+			replicaSet := &appsv1.ReplicaSet{
+				ObjectMeta: v1.ObjectMeta{
+					Name:        plant.Name + "-" + revision,
+					Namespace:   plant.Namespace,
+					Labels:      template.Labels,
+					Annotations: map[string]string{"deployment.kubernetes.io/revision": revision},
+					OwnerReferences: []v1.OwnerReference{
+						*v1.NewControllerRef(deployment, appsv1.SchemeGroupVersion.WithKind("Deployment")),
+					},
+				},
+				Spec: appsv1.ReplicaSetSpec{
+					Selector: &v1.LabelSelector{MatchLabels: template.Labels},
+					Template: *template,
+				},
+			}
+			if err := PlantClient.Create(Ctx, replicaSet); err != nil && !errors.IsAlreadyExists(err) {
+				return err
+			}
+			if deployment.Annotations == nil {
+				deployment.Annotations = make(map[string]string)
+			}
+			deployment.Annotations["deployment.kubernetes.io/revision"] = revision
+			if err := PlantClient.Update(Ctx, deployment); err != nil {
+				return err
+			}
+			deployment.Status = appsv1.DeploymentStatus{
+				ObservedGeneration: deployment.Generation,
+				Replicas:           1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1,
+			}
+			return PlantClient.Status().Update(Ctx, deployment)
+		}
+	}
+
+	It("Should record applied revisions", func() {
+		Eventually(observeRevision("1"), Timeout, Interval).Should(Succeed())
+		Eventually(func() bool {
+			revisions := history()
+			return len(revisions) == 1 && revisions[0].DeploymentRevision == "1" &&
+				revisions[0].Outcome == apiv1.RevisionReady
+		}, Timeout, Interval).Should(BeTrue())
+
+		plant.Spec.Image = "nginx:1.23"
+		SyncPlant(plant)
+		Eventually(func() bool {
+			revisions := history()
+			return len(revisions) == 2 && revisions[1].Revision == 2 && revisions[1].Image == "nginx:1.23" &&
+				revisions[1].Generation == plant.Generation && revisions[1].Outcome == apiv1.RevisionProgressing
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(observeRevision("2"), Timeout, Interval).Should(Succeed())
+	})
+
+	It("Should not record revisions without pod template changes", func() {
+		plant.Spec.Replicas = new(int32)
+		*plant.Spec.Replicas = 2
+		SyncPlant(plant)
+
+		Eventually(func() bool {
+			deployment, err := GetDeployment(plant)
+			return err == nil && *deployment.Spec.Replicas == 2
+		}, Timeout, Interval).Should(BeTrue())
+		Consistently(history, 2*time.Second, Interval).Should(HaveLen(2))
+	})
+
+	It("Should restore pod template of requested revision", func() {
+		revision := int64(1)
+		plant.Spec.RollbackTo = &revision
+		SyncPlant(plant)
+
+		Eventually(deploymentImage, Timeout, Interval).Should(Equal(initialImage))
+		Eventually(func() bool {
+			revisions := history()
+			return len(revisions) == 3 && revisions[2].Image == initialImage &&
+				revisions[2].TemplateHash == revisions[0].TemplateHash
+		}, Timeout, Interval).Should(BeTrue())
+
+		freshPlant, err := GetPlant(plant.Name, plant.Namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(freshPlant.Spec.Image).To(Equal("nginx:1.23"))
+		Expect(*freshPlant.Spec.RollbackTo).To(Equal(revision))
+	})
+
+	It("Should apply Plant pod template once rollback request is removed", func() {
+		plant.Spec.RollbackTo = nil
+		SyncPlant(plant)
+
+		Eventually(deploymentImage, Timeout, Interval).Should(Equal("nginx:1.23"))
+		Eventually(history, Timeout, Interval).Should(HaveLen(4))
+	})
+
+	It("Should keep limited number of revisions", func() {
+		limit := int32(2)
+		plant.Spec.RevisionHistoryLimit = &limit
+		SyncPlant(plant)
+
+		Eventually(func() bool {
+			revisions := history()
+			return len(revisions) == 2 && revisions[1].Revision == 4
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with canary release", Ordered, func() {
+	plant := NewTestPlant("canary-plant")
+	plant.Spec.IngressClassName = utils.Pointer("nginx")
+	stableImage := plant.Spec.Image
+	plant.Spec.Release = &apiv1.Release{Canary: &apiv1.CanaryRelease{
+		Steps: []apiv1.CanaryStep{{Weight: 20, Pause: &v1.Duration{Duration: time.Hour}}},
+	}}
+	RegisterPlant(plant)
+
+	canaryName := func() string { return plant.Name + "-canary" }
+	release := func() *apiv1.ReleaseStatus {
+		freshPlant, err := GetPlant(plant.Name, plant.Namespace)
+		if err != nil {
+			return nil
+		}
+		return freshPlant.Status.Release
+	}
+
+	It("Should split traffic with canary Deployment on image change", func() {
+		Eventually(func() bool {
+			status := release()
+			return status != nil && status.Phase == apiv1.ReleaseStable && status.StableImage == stableImage
+		}, Timeout, Interval).Should(BeTrue())
+
+		plant.Spec.Image = "nginx:1.23"
+		SyncPlant(plant)
+		Eventually(func() bool {
+			status := release()
+			return status != nil && status.Phase == apiv1.ReleaseProgressing && status.Weight == 20 &&
+				status.CanaryImage == "nginx:1.23"
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			canary, err := GetDeploymentByName(canaryName(), plant.Namespace)
+			if err != nil || canary.Spec.Template.Spec.Containers[0].Image != "nginx:1.23" {
+				return false
+			}
+			stable, err := GetDeployment(plant)
+			return err == nil && stable.Spec.Template.Spec.Containers[0].Image == stableImage
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			ingress, err := GetIngressByName(canaryName(), plant.Namespace)
+			return err == nil && ingress.Annotations["nginx.ingress.kubernetes.io/canary-weight"] == "20" &&
+				ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name == canaryName()
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() error {
+			_, err := GetServiceByName(canaryName(), plant.Namespace)
+			return err
+		}, Timeout, Interval).Should(Succeed())
+	})
+
+	It("Should remove canary Deployment on abort", func() {
+		plant.Spec.Release.Canary.Abort = true
+		SyncPlant(plant)
+
+		Eventually(func() bool {
+			status := release()
+			return status != nil && status.Phase == apiv1.ReleaseAborted && status.Weight == 0
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			_, err := GetDeploymentByName(canaryName(), plant.Namespace)
+			_, ingressErr := GetIngressByName(canaryName(), plant.Namespace)
+			return errors.IsNotFound(err) && errors.IsNotFound(ingressErr)
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should promote canary image and remove canary resources after last step", func() {
+		plant.Spec.Release.Canary.Abort = false
+		plant.Spec.Release.Canary.Steps = []apiv1.CanaryStep{{Weight: 50}}
+		SyncPlant(plant)
+
+		// Mark both Deployments ready so that Plant becomes Ready on promotion
+		Eventually(func() error {
+			if _, err := GetDeploymentByName(canaryName(), plant.Namespace); err != nil {
+				return err
+			}
+			if err := MarkDeploymentReady(plant.Name, plant.Namespace); err != nil {
+				return err
+			}
+			return MarkDeploymentReady(canaryName(), plant.Namespace)
+		}, Timeout, Interval).Should(Succeed())
+
+		Eventually(func() bool {
+			status := release()
+			return status != nil && status.Phase == apiv1.ReleaseStable && status.StableImage == "nginx:1.23"
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			stable, err := GetDeployment(plant)
+			return err == nil && stable.Spec.Template.Spec.Containers[0].Image == "nginx:1.23"
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			_, err := GetDeploymentByName(canaryName(), plant.Namespace)
+			_, serviceErr := GetServiceByName(canaryName(), plant.Namespace)
+			_, ingressErr := GetIngressByName(canaryName(), plant.Namespace)
+			return errors.IsNotFound(err) && errors.IsNotFound(serviceErr) && errors.IsNotFound(ingressErr)
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with canary release enabled on image change", Ordered, func() {
+	plant := NewTestPlant("late-canary-plant")
+	plant.Spec.IngressClassName = utils.Pointer("nginx")
+	stableImage := plant.Spec.Image
+	RegisterPlant(plant)
+
+	It("Should release new image with canary Deployment", func() {
+		Eventually(func() error {
+			_, err := GetDeployment(plant)
+			return err
+		}, Timeout, Interval).Should(Succeed())
+
+		plant.Spec.Image = "nginx:1.23"
+		plant.Spec.Release = &apiv1.Release{Canary: &apiv1.CanaryRelease{
+			Steps: []apiv1.CanaryStep{{Weight: 20, Pause: &v1.Duration{Duration: time.Hour}}},
+		}}
+		SyncPlant(plant)
+
+		Eventually(func() bool {
+			freshPlant, err := GetPlant(plant.Name, plant.Namespace)
+			if err != nil || freshPlant.Status.Release == nil {
+				return false
+			}
+			status := freshPlant.Status.Release
+			return status.Phase == apiv1.ReleaseProgressing && status.StableImage == stableImage &&
+				status.CanaryImage == "nginx:1.23"
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			stable, err := GetDeployment(plant)
+			return err == nil && stable.Spec.Template.Spec.Containers[0].Image == stableImage
+		}, Timeout, Interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Plant with canary release analysis", Ordered, func() {
+	var errorRate atomic.Value
+	var queries sync.Map
+	errorRate.Store("")
+
+	plant := NewTestPlant("analysis-plant")
+	plant.Spec.IngressClassName = utils.Pointer("nginx")
+	stableImage := plant.Spec.Image
+	plant.Spec.Release = &apiv1.Release{Canary: &apiv1.CanaryRelease{
+		Steps: []apiv1.CanaryStep{{Weight: 20}},
+		Analysis: &apiv1.ReleaseAnalysis{
+			Metrics: []apiv1.AnalysisMetric{{
+				Name:  "error-rate",
+				Query: `sum(rate(http_requests_total{namespace="{{ .Namespace }}",pod=~"{{ .Canary }}-.*",code=~"5.."}[1m]))`,
+				Max:   "0.05",
+			}},
+		},
+	}}
+
+	BeforeAll(func() {
+		prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			queries.Store(r.URL.Query().Get("query"), true)
+			result := "[]"
+			if value := errorRate.Load().(string); value != "" {
+				result = fmt.Sprintf(`[{"metric":{},"value":[1700000000,%q]}]`, value)
+			}
+			_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":%s}}`, result)
+		}))
+		DeferCleanup(prometheus.Close)
+		plant.Spec.Release.Canary.Analysis.Address = prometheus.URL
+	})
+	RegisterPlant(plant)
+
+	canaryName := plant.Name + "-canary"
+	freshPlant := func() *apiv1.Plant {
+		freshPlant, err := GetPlant(plant.Name, plant.Namespace)
+		if err != nil {
+			return &apiv1.Plant{}
+		}
+		return freshPlant
+	}
+	analysisCondition := func(status v1.ConditionStatus, reason string) func() bool {
+		return func() bool {
+			condition := meta.FindStatusCondition(freshPlant().Status.Conditions, string(apiv1.ConditionTypeReleaseAnalysis))
+			return condition != nil && condition.Status == status && condition.Reason == reason
+		}
+	}
+	markCanaryReady := func(image string) {
+		Eventually(func() error {
+			canary, err := GetDeploymentByName(canaryName, plant.Namespace)
+			if err != nil {
+				return err
+			}
+			if canary.Spec.Template.Spec.Containers[0].Image != image {
+				return fmt.Errorf("canary Deployment does not release image %s", image)
+			}
+			canary.Status = appsv1.DeploymentStatus{
+				ObservedGeneration: canary.Generation,
+				Replicas:           1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1,
+			}
+			return PlantClient.Status().Update(Ctx, canary)
+		}, Timeout, Interval).Should(Succeed())
+	}
+
+	It("Should keep canary step while analysis is inconclusive", func() {
+		Eventually(func() bool {
+			release := freshPlant().Status.Release
+			return release != nil && release.Phase == apiv1.ReleaseStable
+		}, Timeout, Interval).Should(BeTrue())
+
+		plant.Spec.Image = "nginx:1.23"
+		SyncPlant(plant)
+		markCanaryReady("nginx:1.23")
+
+		Eventually(analysisCondition(v1.ConditionFalse, "AnalysisInconclusive"), Timeout, Interval).Should(BeTrue())
+		release := freshPlant().Status.Release
+		Expect(release.Phase).To(Equal(apiv1.ReleaseProgressing))
+		Expect(release.StableImage).To(Equal(stableImage))
+
+		By("rendering queries with canary resource names")
+		_, rendered := queries.Load(fmt.Sprintf(`sum(rate(http_requests_total{namespace="%s",pod=~"%s-.*",code=~"5.."}[1m]))`,
+			plant.Namespace, canaryName))
+		Expect(rendered).To(BeTrue())
+	})
+
+	It("Should fail release when analysis fails", func() {
+		errorRate.Store("0.5")
+
+		Eventually(analysisCondition(v1.ConditionFalse, "AnalysisFailed"), Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			release := freshPlant().Status.Release
+			return release != nil && release.Phase == apiv1.ReleaseFailed && release.StableImage == stableImage
+		}, Timeout, Interval).Should(BeTrue())
+		Eventually(func() bool {
+			_, err := GetDeploymentByName(canaryName, plant.Namespace)
+			return errors.IsNotFound(err)
+		}, Timeout, Interval).Should(BeTrue())
+	})
+
+	It("Should promote canary image when analysis passes", func() {
+		errorRate.Store("0.01")
+		plant.Spec.Image = "nginx:1.24"
+		SyncPlant(plant)
+		markCanaryReady("nginx:1.24")
+
+		Eventually(func() bool {
+			release := freshPlant().Status.Release
+			return release != nil && release.Phase == apiv1.ReleaseStable && release.StableImage == "nginx:1.24"
+		}, Timeout, Interval).Should(BeTrue())
+		Expect(analysisCondition(v1.ConditionTrue, "AnalysisPassed")()).To(BeTrue())
+	})
+
+	It("Should not query addresses which are not allowed", func() {
+		plant.Spec.Release.Canary.Analysis.Address = "http://metadata.internal"
+		plant.Spec.Image = "nginx:1.25"
+		SyncPlant(plant)
+		markCanaryReady("nginx:1.25")
+
+		Eventually(func() bool {
+			condition := meta.FindStatusCondition(freshPlant().Status.Conditions, string(apiv1.ConditionTypeReleaseAnalysis))
+			return condition != nil && condition.Status == v1.ConditionFalse &&
+				strings.Contains(condition.Message, "is not allowed by the operator")
+		}, Timeout, Interval).Should(BeTrue())
+		Expect(freshPlant().Status.Release.Phase).To(Equal(apiv1.ReleaseProgressing))
+	})
+})
+
+var _ = Describe("Plant with referenced configuration", Ordered, func() {
+	plant := NewTestPlant("config-plant")
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: plant.Name + "-config", Namespace: plant.Namespace},
+		Data:       map[string]string{"LOG_LEVEL": "debug"},
+	}
+	plant.Spec.EnvFrom = []corev1.EnvFromSource{{
+		ConfigMapRef: &corev1.ConfigMapEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+		},
+	}}
+
+	BeforeAll(func() {
+		Expect(PlantClient.Create(Ctx, configMap)).NotTo(HaveOccurred())
+	})
+	RegisterPlant(plant)
+
+	var configHash string
+	It("Should annotate pod template with configuration hash", func() {
+		Eventually(func() string {
+			configHash = GetPodTemplateAnnotation(plant, apiv1.ConfigHashAnnotation)
+			return configHash
+		}, Timeout, Interval).ShouldNot(BeEmpty())
+	})
+
+	It("Should update configuration hash when ConfigMap changes", func() {
+		configMap.Data["LOG_LEVEL"] = "info"
+		Expect(PlantClient.Update(Ctx, configMap)).NotTo(HaveOccurred())
+
+		Eventually(func() string {
+			return GetPodTemplateAnnotation(plant, apiv1.ConfigHashAnnotation)
+		}, Timeout, Interval).ShouldNot(Equal(configHash))
+	})
+
+	It("Should remove configuration hash when references are removed", func() {
+		plant.Spec.EnvFrom = nil
+
+		SyncPlant(plant)
+		Eventually(func() string {
+			return GetPodTemplateAnnotation(plant, apiv1.ConfigHashAnnotation)
+		}, Timeout, Interval).Should(BeEmpty())
+	})
+})
+
 ////E2E tests (disabled since I do have a running k8s CI/CD flow)
 //var _ = Describe("Default plant with image and host", Ordered, func() {
 //	plant := NewTestPlant("basic-plant")
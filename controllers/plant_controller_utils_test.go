@@ -7,13 +7,17 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"math/rand"
 	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	"time"
 )
 
@@ -86,30 +90,103 @@ func getPlantReplicas(plant *apiv1.Plant) int32 {
 	return *plant.Spec.Replicas
 }
 
+func getPlantResources(plant *apiv1.Plant) corev1.ResourceRequirements {
+	if resources := plant.GetResources(); resources != nil {
+		return *resources
+	}
+	return corev1.ResourceRequirements{}
+}
+
 func GetDeployment(p *apiv1.Plant) (*appsv1.Deployment, error) {
+	return GetDeploymentByName(p.Name, p.Namespace)
+}
+
+func GetDeploymentByName(name, namespace string) (*appsv1.Deployment, error) {
 	deployment := &appsv1.Deployment{}
-	if err := PlantClient.Get(Ctx, client.ObjectKey{Name: p.Name, Namespace: p.Namespace}, deployment); err != nil {
+	if err := PlantClient.Get(Ctx, client.ObjectKey{Name: name, Namespace: namespace}, deployment); err != nil {
 		return nil, err
 	}
 	return deployment, nil
 }
 
+// MarkDeploymentReady reports the current Deployment generation as rolled out since
+// Deployments are not processed in the test environment
+func MarkDeploymentReady(name, namespace string) error {
+	deployment, err := GetDeploymentByName(name, namespace)
+	if err != nil {
+		return err
+	}
+	deployment.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: deployment.Generation,
+		Replicas:           1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1,
+	}
+	return PlantClient.Status().Update(Ctx, deployment)
+}
+
+func GetPodTemplateAnnotation(p *apiv1.Plant, key string) string {
+	deployment, err := GetDeployment(p)
+	if err != nil {
+		return ""
+	}
+	return deployment.Spec.Template.Annotations[key]
+}
+
+func GetAutoscaler(p *apiv1.Plant) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	autoscaler := &autoscalingv2.HorizontalPodAutoscaler{}
+	if err := PlantClient.Get(Ctx, client.ObjectKey{Name: p.Name, Namespace: p.Namespace}, autoscaler); err != nil {
+		return nil, err
+	}
+	return autoscaler, nil
+}
+
+func GetDisruptionBudget(p *apiv1.Plant) (*policyv1.PodDisruptionBudget, error) {
+	budget := &policyv1.PodDisruptionBudget{}
+	if err := PlantClient.Get(Ctx, client.ObjectKey{Name: p.Name, Namespace: p.Namespace}, budget); err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+func GetClaim(p *apiv1.Plant) (*corev1.PersistentVolumeClaim, error) {
+	claim := &corev1.PersistentVolumeClaim{}
+	if err := PlantClient.Get(Ctx, client.ObjectKey{Name: p.Name, Namespace: p.Namespace}, claim); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
 func GetService(p *apiv1.Plant) (*corev1.Service, error) {
+	return GetServiceByName(p.Name, p.Namespace)
+}
+
+func GetServiceByName(name, namespace string) (*corev1.Service, error) {
 	service := &corev1.Service{}
-	if err := PlantClient.Get(Ctx, client.ObjectKey{Name: p.Name, Namespace: p.Namespace}, service); err != nil {
+	if err := PlantClient.Get(Ctx, client.ObjectKey{Name: name, Namespace: namespace}, service); err != nil {
 		return nil, err
 	}
 	return service, nil
 }
 
 func GetIngress(p *apiv1.Plant) (*networkingv1.Ingress, error) {
+	return GetIngressByName(p.Name, p.Namespace)
+}
+
+func GetIngressByName(name, namespace string) (*networkingv1.Ingress, error) {
 	ingress := &networkingv1.Ingress{}
-	if err := PlantClient.Get(Ctx, client.ObjectKey{Name: p.Name, Namespace: p.Namespace}, ingress); err != nil {
+	if err := PlantClient.Get(Ctx, client.ObjectKey{Name: name, Namespace: namespace}, ingress); err != nil {
 		return nil, err
 	}
 	return ingress, nil
 }
 
+func GetRoute(name, namespace string) (*gatewayv1beta1.HTTPRoute, error) {
+	route := &gatewayv1beta1.HTTPRoute{}
+	if err := PlantClient.Get(Ctx, client.ObjectKey{Name: name, Namespace: namespace}, route); err != nil {
+		return nil, err
+	}
+	return route, nil
+}
+
 func GetCertificate(p *apiv1.Plant) (*cmv1.Certificate, error) {
 	cert := &cmv1.Certificate{}
 	if err := PlantClient.Get(Ctx, client.ObjectKey{Name: p.Name, Namespace: p.Namespace}, cert); err != nil {
@@ -146,7 +223,10 @@ func UNIT_IsPlantValid(plant *apiv1.Plant) func() bool {
 		found := false
 	mainLoop:
 		for _, container := range deployment.Spec.Template.Spec.Containers {
-			if container.Image == plant.Spec.Image {
+			if container.Image == plant.Spec.Image &&
+				equality.Semantic.DeepEqual(container.Env, plant.Spec.Env) &&
+				equality.Semantic.DeepEqual(container.EnvFrom, plant.Spec.EnvFrom) &&
+				equality.Semantic.DeepEqual(container.Resources, getPlantResources(plant)) {
 				for _, port := range container.Ports {
 					if GetPlantPort(plant) == port.ContainerPort {
 						found = true
@@ -0,0 +1,221 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"github.com/fhivemind/plant-operator/controllers/workflow"
+	"github.com/fhivemind/plant-operator/pkg/analysis"
+	"github.com/fhivemind/plant-operator/pkg/resource"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"time"
+)
+
+// releaseAnalysisTimeout defines the deadline of all release analysis queries during a reconcile
+const releaseAnalysisTimeout = 5 * time.Second
+
+// PlanRelease updates the release state of Plant before workflow execution. It starts
+// a canary release when Image differs from the stable image, and handles restarts,
+// aborts and reverts of the current release. The stable image of new releases is
+// taken from the existing Deployment. Release state will be sent with the next
+// status update.
+func (r *PlantReconciler) PlanRelease(ctx context.Context, plant *apiv1.Plant) error {
+	if plant.GetReleaseAnalysis() == nil {
+		plant.RemoveCondition(apiv1.ConditionTypeReleaseAnalysis)
+	}
+
+	// Release directly if canary releases are not requested
+	if plant.Spec.Release == nil || plant.Spec.Release.Canary == nil {
+		plant.Status.Release = nil
+		return nil
+	}
+	canary := plant.Spec.Release.Canary
+	release := plant.Status.Release
+	if release == nil {
+		stableImage, err := r.deployedImage(ctx, plant)
+		if err != nil {
+			return fmt.Errorf("could not resolve stable image: %w", err)
+		}
+		plant.Status.Release = &apiv1.ReleaseStatus{Phase: apiv1.ReleaseStable, StableImage: stableImage}
+		release = plant.Status.Release
+	}
+
+	// Update release state
+	switch {
+	case plant.Spec.Image == release.StableImage: // nothing to release, or release reverted
+		if release.Phase != apiv1.ReleaseStable {
+			*release = apiv1.ReleaseStatus{Phase: apiv1.ReleaseStable, StableImage: release.StableImage}
+			plant.RemoveCondition(apiv1.ConditionTypeReleaseAnalysis)
+			r.Recorder.Eventf(plant, v1.EventTypeNormal, "ReleaseReverted", "Image reverted to stable image %s", release.StableImage)
+		}
+		return nil
+
+	case plant.Spec.Image != release.CanaryImage || (release.Phase == apiv1.ReleaseAborted && !canary.Abort):
+		release.Phase = apiv1.ReleaseProgressing
+		release.CanaryImage = plant.Spec.Image
+		release.Step = 0
+		release.StepStartTime = &metav1.Time{Time: time.Now()}
+		plant.RemoveCondition(apiv1.ConditionTypeReleaseAnalysis)
+		r.Recorder.Eventf(plant, v1.EventTypeNormal, "ReleaseStarted", "Started canary release of image %s", release.CanaryImage)
+	}
+
+	// Handle abort requests and step changes
+	switch {
+	case release.Phase != apiv1.ReleaseProgressing:
+		return nil
+
+	case canary.Abort:
+		release.Phase = apiv1.ReleaseAborted
+		release.Weight = 0
+		release.StepStartTime = nil
+		r.Recorder.Eventf(plant, v1.EventTypeWarning, "ReleaseAborted", "Aborted canary release of image %s", release.CanaryImage)
+
+	case int(release.Step) >= len(canary.Steps): // steps were removed, continue from the last one
+		release.Step = int32(len(canary.Steps) - 1)
+		fallthrough
+
+	default:
+		release.Weight = canary.Steps[release.Step].Weight
+	}
+	return nil
+}
+
+// deployedImage returns the image of the existing stable Deployment, or Image if it does not exist yet.
+func (r *PlantReconciler) deployedImage(ctx context.Context, plant *apiv1.Plant) (string, error) {
+	deployment := &appsv1.Deployment{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: plant.Namespace, Name: plant.Name}, deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return plant.Spec.Image, nil
+		}
+		return "", err
+	}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name == plant.Name {
+			return container.Image, nil
+		}
+	}
+	return plant.Spec.Image, nil
+}
+
+// AdvanceRelease proceeds with the canary release after workflow execution. The release
+// moves to the next step once the canary Deployment is ready, the step pause has passed
+// and the release analysis has passed, and promotes the canary image to the stable
+// Deployment after the last step. Fails the release if the canary Deployment exceeds
+// its progress deadline or if the release analysis fails.
+// Returns true if the release state changed and has to be applied by the workflow.
+func (r *PlantReconciler) AdvanceRelease(ctx context.Context, plant *apiv1.Plant, results []resource.ExecuteResult) bool {
+	// Check if release is in progress
+	if !plant.CanaryActive() {
+		return false
+	}
+	release := plant.Status.Release
+	steps := plant.Spec.Release.Canary.Steps
+	canaryResult := findResult(results, "CanaryDeployment")
+
+	// Check if current step is done
+	switch {
+	case errors.Is(canaryResult.Error(), workflow.ProgressDeadlineExceededErr):
+		r.failRelease(plant, canaryResult.Error().Error())
+		return true
+
+	case !canaryResult.Ready() || releasePauseRemaining(plant) > 0:
+		return false // keep current step
+	}
+
+	// Check if current step passes analysis
+	switch verdict, message := r.AnalyzeRelease(ctx, plant); verdict {
+	case analysis.Failed:
+		r.failRelease(plant, fmt.Sprintf("analysis failed: %s", message))
+		return true
+
+	case analysis.Inconclusive:
+		return false // keep current step, analysis is retried on requeue
+	}
+
+	// Advance release
+	if int(release.Step) < len(steps)-1 {
+		release.Step++
+		release.Weight = steps[release.Step].Weight
+		release.StepStartTime = &metav1.Time{Time: time.Now()}
+		r.Recorder.Eventf(plant, v1.EventTypeNormal, "ReleaseStep", "Canary release of image %s proceeded to step %d with weight %d",
+			release.CanaryImage, release.Step, release.Weight)
+		return true
+	}
+	*release = apiv1.ReleaseStatus{Phase: apiv1.ReleaseStable, StableImage: release.CanaryImage}
+	r.Recorder.Eventf(plant, v1.EventTypeNormal, "ReleasePromoted", "Promoted image %s to stable Deployment", release.StableImage)
+	return true
+}
+
+// AnalyzeRelease evaluates release analysis metrics of Plant within releaseAnalysisTimeout and
+// reports the verdict with ConditionTypeReleaseAnalysis condition. Passes if analysis is not
+// requested, and is inconclusive if the address is not allowed by AnalysisAddresses.
+func (r *PlantReconciler) AnalyzeRelease(ctx context.Context, plant *apiv1.Plant) (analysis.Verdict, string) {
+	releaseAnalysis := plant.GetReleaseAnalysis()
+	if releaseAnalysis == nil {
+		return analysis.Passed, ""
+	}
+	if !analysis.AddressAllowed(releaseAnalysis.Address, r.AnalysisAddresses) {
+		message := fmt.Sprintf("address %s is not allowed by the operator", releaseAnalysis.Address)
+		plant.UpdateCondition(apiv1.ConditionTypeReleaseAnalysis, false, "AnalysisInconclusive", message)
+		return analysis.Inconclusive, message
+	}
+
+	// Evaluate metrics
+	metrics := make([]analysis.Metric, 0, len(releaseAnalysis.Metrics))
+	for _, metric := range releaseAnalysis.Metrics {
+		query, err := metric.RenderQuery(plant)
+		if err != nil {
+			message := fmt.Sprintf("%s: invalid query: %v", metric.Name, err)
+			plant.UpdateCondition(apiv1.ConditionTypeReleaseAnalysis, false, "AnalysisInconclusive", message)
+			return analysis.Inconclusive, message
+		}
+		minValue, _ := metric.GetMinThreshold() // thresholds are validated by webhook
+		maxValue, _ := metric.GetMaxThreshold()
+		metrics = append(metrics, analysis.Metric{Name: metric.Name, Query: query, Min: minValue, Max: maxValue})
+	}
+	analysisCtx, cancel := context.WithTimeout(ctx, releaseAnalysisTimeout)
+	defer cancel()
+	verdict, message := analysis.Summarize(analysis.NewClient(releaseAnalysis.Address).Analyze(analysisCtx, metrics))
+
+	// Report verdict
+	plant.UpdateCondition(apiv1.ConditionTypeReleaseAnalysis, verdict == analysis.Passed, "Analysis"+verdict.String(),
+		fmt.Sprintf("Analysis of step %d of image %s: %s", plant.Status.Release.Step, plant.Status.Release.CanaryImage, message))
+	return verdict, message
+}
+
+// failRelease stops the canary release and sends all traffic to the stable Deployment
+func (r *PlantReconciler) failRelease(plant *apiv1.Plant, reason string) {
+	release := plant.Status.Release
+	release.Phase = apiv1.ReleaseFailed
+	release.Weight = 0
+	release.StepStartTime = nil
+	r.Recorder.Eventf(plant, v1.EventTypeWarning, "ReleaseFailed", "Canary release of image %s failed: %s", release.CanaryImage, reason)
+}
+
+// releasePauseRemaining returns the remaining pause of the current canary step
+func releasePauseRemaining(plant *apiv1.Plant) time.Duration {
+	if !plant.CanaryActive() || plant.Status.Release.StepStartTime == nil {
+		return 0
+	}
+	release := plant.Status.Release
+	pause := plant.Spec.Release.Canary.Steps[release.Step].Pause
+	if pause == nil {
+		return 0
+	}
+	return time.Until(release.StepStartTime.Add(pause.Duration))
+}
+
+// findResult returns the named execution result, or an empty result if not found
+func findResult(results []resource.ExecuteResult, name string) resource.ExecuteResult {
+	for _, res := range results {
+		if res.Name() == name {
+			return res
+		}
+	}
+	return resource.ExecuteResult{}
+}
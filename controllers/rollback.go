@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"github.com/fhivemind/plant-operator/controllers/workflow"
+	"github.com/fhivemind/plant-operator/pkg/resource"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HandleRollback reverts the Deployment to the last ready revision if the rollout failed
+// and Plant requests automatic rollbacks. Rollback details are recorded in Plant status,
+// which will be sent with the next status update, and cleared once Plant defines another
+// pod template. Returns true if the rollback was performed.
+func (r *PlantReconciler) HandleRollback(ctx context.Context, plant *apiv1.Plant, results []resource.ExecuteResult) (bool, error) {
+	res := findResult(results, "Deployment")
+	deployment, ok := res.Object().(*appsv1.Deployment)
+	if ok && plant.Status.Rollback != nil &&
+		deployment.Annotations[apiv1.TemplateHashAnnotation] != plant.Status.Rollback.FailedTemplateHash {
+		plant.Status.Rollback = nil
+	}
+
+	// Check if rollback is required
+	switch {
+	case plant.Spec.Rollback == nil || !plant.Spec.Rollback.Enabled:
+		return false, nil
+	case !ok || !errors.Is(res.Error(), workflow.ProgressDeadlineExceededErr):
+		return false, nil
+	case plant.Status.Rollback != nil || plant.Status.LastReadyRevision == "":
+		return false, nil // nothing to roll back to
+	}
+
+	// Rollback
+	revision := plant.Status.LastReadyRevision
+	if err := r.Workflow.WithClient(r.Client).Rollback(ctx, plant, revision); err != nil {
+		return false, fmt.Errorf("could not roll back Deployment to revision %s: %w", revision, err)
+	}
+	plant.Status.Rollback = &apiv1.RollbackStatus{
+		FailedImage:        plant.Spec.Image,
+		FailedGeneration:   plant.Generation,
+		FailedTemplateHash: deployment.Annotations[apiv1.TemplateHashAnnotation],
+		Revision:           revision,
+		Time:               metav1.Now(),
+	}
+	r.Recorder.Eventf(plant, v1.EventTypeWarning, "RolledBack",
+		"Rollout of image %s failed, rolled back to revision %s", plant.Spec.Image, revision)
+	return true, nil
+}
+
+// HandleRollbackTo reverts the Deployment pod template to the revision requested by RollbackTo.
+// Plant spec is left as is, and its pod template is applied again once the request is removed.
+// Revisions which are no longer recorded in history are reported and ignored.
+// Returns true if the rollback was performed.
+func (r *PlantReconciler) HandleRollbackTo(ctx context.Context, plant *apiv1.Plant, results []resource.ExecuteResult) (bool, error) {
+	// Check if rollback is required
+	if plant.Spec.RollbackTo == nil {
+		return false, nil
+	}
+	requested := *plant.Spec.RollbackTo
+	revision := plant.GetRevision(requested)
+	deployment, ok := findResult(results, "Deployment").Object().(*appsv1.Deployment)
+	switch {
+	case revision == nil:
+		r.Recorder.Eventf(plant, v1.EventTypeWarning, "RollbackRevisionNotFound",
+			"Revision %d not found in Plant history, rollback ignored", requested)
+		return false, nil
+	case !ok || workflow.PodTemplateHash(deployment) == revision.TemplateHash:
+		return false, nil // already applied
+	case revision.DeploymentRevision == "":
+		return false, fmt.Errorf("could not roll back to revision %d: Deployment revision not recorded", requested)
+	}
+
+	// Rollback
+	if err := r.Workflow.WithClient(r.Client).Rollback(ctx, plant, revision.DeploymentRevision); err != nil {
+		return false, fmt.Errorf("could not roll back Deployment to revision %d: %w", requested, err)
+	}
+	r.Recorder.Eventf(plant, v1.EventTypeNormal, "RolledBackTo",
+		"Rolled back to image %s of revision %d", revision.Image, revision.Revision)
+	return true, nil
+}
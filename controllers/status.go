@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"github.com/fhivemind/plant-operator/controllers/workflow"
 	"github.com/fhivemind/plant-operator/pkg/resource"
 	"github.com/fhivemind/plant-operator/pkg/utils"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"strings"
 )
 
@@ -30,6 +33,8 @@ func (r *PlantReconciler) UpdateStatus(ctx context.Context, plant *apiv1.Plant,
 // UpdateResults will handle results from executions by adding them to Plant status
 func (r *PlantReconciler) UpdateResults(ctx context.Context, plant *apiv1.Plant, results []resource.ExecuteResult) error {
 	plant.Status.Resources = make([]apiv1.ResourceStatus, 0)
+	plant.Status.Selector = labels.SelectorFromSet(plant.OperatorLabels()).String()
+	plant.Status.URL = plant.GetURL()
 
 	// Handle child resources
 	for _, res := range results {
@@ -41,13 +46,16 @@ func (r *PlantReconciler) UpdateResults(ctx context.Context, plant *apiv1.Plant,
 		reason := "WaitingReadyState"
 		state := apiv1.StateProcessing
 		message := fmt.Sprintf("Resource %s is in Not Ready state", resType)
+		if reason := res.NotReadyReason(); reason != "" {
+			message = fmt.Sprintf("%s: %s", message, reason)
+		}
 
 		switch {
 		case res.Errored(): // ERROR STATE
 			state = apiv1.StateError
-			message = fmt.Sprintf("Resource %s is in Error state", resType)
+			message = fmt.Sprintf("Resource %s is in Error state: %v", resType, res.Error())
 
-			r.Recorder.Eventf(plant, v1.EventTypeWarning, "Error", "Rescheduling as %s: %v", message, res.Error())
+			r.Recorder.Eventf(plant, v1.EventTypeWarning, "Error", "Rescheduling as %s", message)
 			break
 
 		case res.Skipped(): // SKIPPED STATE
@@ -55,6 +63,9 @@ func (r *PlantReconciler) UpdateResults(ctx context.Context, plant *apiv1.Plant,
 			reason = "ProcessingSkipped"
 			state = apiv1.StateReady
 			message = fmt.Sprintf("Resource %s skipped due to conditions", resType)
+			if ops := res.ProcessingOps(); len(ops) > 0 {
+				message = fmt.Sprintf("%s after %s ops", message, strings.Join(ops, ", "))
+			}
 
 		case res.Ready(): // READY STATE
 			ready = true
@@ -66,6 +77,22 @@ func (r *PlantReconciler) UpdateResults(ctx context.Context, plant *apiv1.Plant,
 			}
 		}
 
+		// Update observed replicas
+		if deployment, ok := resObj.(*appsv1.Deployment); ok && res.Name() == "Deployment" {
+			plant.Status.Replicas = deployment.Status.Replicas
+			plant.Status.ReadyReplicas = deployment.Status.ReadyReplicas
+			if res.Ready() {
+				plant.Status.LastReadyRevision = workflow.DeploymentRevision(deployment)
+			}
+			recordRevision(plant, res)
+		}
+
+		// Report rolled back Deployment
+		if res.Name() == "Deployment" && plant.RolledBack() {
+			message = fmt.Sprintf("%s, rolled back to revision %s after image %s failed to roll out",
+				message, plant.Status.Rollback.Revision, plant.Status.Rollback.FailedImage)
+		}
+
 		// Update plant conditions and resources
 		plant.UpdateCondition(apiv1.ConditionTypeAvailableFor(res.Name()), ready, reason, message)
 		if !res.Skipped() || resObj != nil { // only add non-ignored and non-nil results
@@ -28,6 +28,7 @@ import (
 	"os"
 	"path/filepath"
 	ctrl "sigs.k8s.io/controller-runtime"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	"testing"
 	"time"
 
@@ -77,6 +78,7 @@ var _ = BeforeSuite(func() {
 		CRDs: loadExternalCrds(
 			filepath.Join("..", "config", "samples", "test", "crds"),
 			"cert-manager.v1.11.0.crds.yaml",
+			"gateway-api.v0.6.0.httproutes.crds.yaml",
 		),
 		ErrorIfCRDPathMissing: true,
 	}
@@ -90,6 +92,7 @@ var _ = BeforeSuite(func() {
 	// register test
 	Expect(operatorv1.AddToScheme(scheme.Scheme)).NotTo(HaveOccurred())
 	Expect(certv1.AddToScheme(scheme.Scheme)).NotTo(HaveOccurred())
+	Expect(gatewayv1beta1.AddToScheme(scheme.Scheme)).NotTo(HaveOccurred())
 
 	//+kubebuilder:scaffold:scheme
 
@@ -99,10 +102,11 @@ var _ = BeforeSuite(func() {
 
 	// configure reconciler
 	err = (&controllers.PlantReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Workflow: workflow.NewManager(),
-		Recorder: mgr.GetEventRecorderFor("plant-controller"),
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		Workflow:          workflow.NewManager(workflow.WithGatewayAPI(), workflow.WithConfigReader(mgr.GetAPIReader())),
+		Recorder:          mgr.GetEventRecorderFor("plant-controller"),
+		AnalysisAddresses: []string{"http://127.0.0.1"}, // fake Prometheus servers listen on random ports
 	}).SetupWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
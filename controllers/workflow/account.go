@@ -0,0 +1,254 @@
+package workflow
+
+import (
+	"context"
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"github.com/fhivemind/plant-operator/pkg/resource"
+	"github.com/fhivemind/plant-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// newServiceAccountOrRemoveHandler creates either a ServiceAccount resource.Executor or a
+// resource.RemoveExecutor depending on the state of Plant.
+// ServiceAccounts controlled by Plant which are no longer expected, e.g. after
+// renaming, are removed in both cases.
+func (m *manager) newServiceAccountOrRemoveHandler(plant *apiv1.Plant) resource.Executor[*corev1.ServiceAccount] {
+	if plant.Spec.ServiceAccount == nil || !plant.Spec.ServiceAccount.Create {
+		return resource.RemoveExecutor[*corev1.ServiceAccount]("ServiceAccount",
+			func(ctx context.Context, object *corev1.ServiceAccount) error {
+				return m.fetchStaleServiceAccount(ctx, plant, "", object)
+			},
+			func(ctx context.Context, object *corev1.ServiceAccount) error {
+				return m.Client().Delete(ctx, object)
+			},
+		)
+	}
+
+	// Create expected object
+	expected := defineServiceAccount(plant)
+	m.Client().Scheme().Default(expected)
+
+	// Return handler
+	return resource.Executor[*corev1.ServiceAccount]{
+		Name: "ServiceAccount",
+		FetchFunc: func(ctx context.Context, object *corev1.ServiceAccount) error {
+			return m.Client().Get(ctx, types.NamespacedName{Namespace: expected.Namespace, Name: expected.Name}, object)
+		},
+		CreateFunc: func(ctx context.Context, object *corev1.ServiceAccount) error {
+			expected.DeepCopyInto(object) // fill with required values
+			if err := controllerutil.SetControllerReference(plant, object, m.Client().Scheme()); err != nil {
+				return err
+			}
+			return m.Client().Create(ctx, object)
+		},
+		UpdateFunc: func(ctx context.Context, object *corev1.ServiceAccount) (bool, error) {
+			stale := &corev1.ServiceAccount{}
+			if err := m.fetchStaleServiceAccount(ctx, plant, expected.Name, stale); client.IgnoreNotFound(err) != nil {
+				return false, err
+			} else if err == nil {
+				if err := m.Client().Delete(ctx, stale); client.IgnoreNotFound(err) != nil {
+					return false, err
+				}
+			}
+
+			annotationsPruned := pruneManagedAnnotations(expected.Annotations, object.Annotations)
+			annotationsChanged := !utils.MapContains(object.Annotations, expected.Annotations)
+			automountChanged := !reflect.DeepEqual(expected.AutomountServiceAccountToken, object.AutomountServiceAccountToken)
+			if annotationsPruned || annotationsChanged || automountChanged {
+				object.AutomountServiceAccountToken = expected.AutomountServiceAccountToken
+				utils.MergeMapsSrcDst(expected.Labels, object.Labels)
+				if object.Annotations == nil {
+					object.Annotations = make(map[string]string)
+				}
+				utils.MergeMapsSrcDst(expected.Annotations, object.Annotations)
+				return true, m.Client().Update(ctx, object)
+			}
+			return false, nil
+		},
+		IsReady: func(_ context.Context, object *corev1.ServiceAccount) bool {
+			return true
+		},
+	}
+}
+
+// fetchStaleServiceAccount fetches a ServiceAccount controlled by Plant which is not named as expected.
+// Returns a NotFound error if no such ServiceAccount exists.
+func (m *manager) fetchStaleServiceAccount(ctx context.Context, plant *apiv1.Plant, expected string, object *corev1.ServiceAccount) error {
+	accounts := &corev1.ServiceAccountList{}
+	if err := m.Client().List(ctx, accounts,
+		client.InNamespace(plant.Namespace), client.MatchingLabels(plant.OperatorLabels())); err != nil {
+		return err
+	}
+	for i := range accounts.Items {
+		item := &accounts.Items[i]
+		if item.Name != expected && metav1.IsControlledBy(item, plant) {
+			item.DeepCopyInto(object)
+			return nil
+		}
+	}
+	return errors.NewNotFound(corev1.Resource("serviceaccounts"), plant.Name)
+}
+
+// newRoleOrRemoveHandler creates either a Role resource.Executor or a resource.RemoveExecutor
+// depending on the state of Plant. Role is required when ServiceAccount rules are specified.
+func (m *manager) newRoleOrRemoveHandler(plant *apiv1.Plant) resource.Executor[*rbacv1.Role] {
+	if !roleRequired(plant) {
+		return newRemoveHandler[*rbacv1.Role](m, "Role", plant, plant.Name)
+	}
+
+	// Create expected object
+	expected := defineRole(plant)
+	m.Client().Scheme().Default(expected)
+
+	// Return handler
+	return resource.Executor[*rbacv1.Role]{
+		Name: "Role",
+		FetchFunc: func(ctx context.Context, object *rbacv1.Role) error {
+			return m.Client().Get(ctx, types.NamespacedName{Namespace: expected.Namespace, Name: expected.Name}, object)
+		},
+		CreateFunc: func(ctx context.Context, object *rbacv1.Role) error {
+			expected.DeepCopyInto(object) // fill with required values
+			if err := controllerutil.SetControllerReference(plant, object, m.Client().Scheme()); err != nil {
+				return err
+			}
+			return m.Client().Create(ctx, object)
+		},
+		UpdateFunc: func(ctx context.Context, object *rbacv1.Role) (bool, error) {
+			if !equality.Semantic.DeepEqual(expected.Rules, object.Rules) {
+				object.Rules = expected.Rules
+				utils.MergeMapsSrcDst(expected.Labels, object.Labels)
+				return true, m.Client().Update(ctx, object)
+			}
+			return false, nil
+		},
+		IsReady: func(_ context.Context, object *rbacv1.Role) bool {
+			return true
+		},
+	}
+}
+
+// newRoleBindingOrRemoveHandler creates either a RoleBinding resource.Executor or a resource.RemoveExecutor
+// depending on the state of Plant. RoleBinding is required when ServiceAccount rules are specified
+// or ServiceAccount references a role.
+func (m *manager) newRoleBindingOrRemoveHandler(plant *apiv1.Plant) resource.Executor[*rbacv1.RoleBinding] {
+	if !roleBindingRequired(plant) {
+		return newRemoveHandler[*rbacv1.RoleBinding](m, "RoleBinding", plant, plant.Name)
+	}
+
+	// Create expected object
+	expected := defineRoleBinding(plant)
+	m.Client().Scheme().Default(expected)
+
+	// Return handler
+	return resource.Executor[*rbacv1.RoleBinding]{
+		Name: "RoleBinding",
+		FetchFunc: func(ctx context.Context, object *rbacv1.RoleBinding) error {
+			return m.Client().Get(ctx, types.NamespacedName{Namespace: expected.Namespace, Name: expected.Name}, object)
+		},
+		CreateFunc: func(ctx context.Context, object *rbacv1.RoleBinding) error {
+			expected.DeepCopyInto(object) // fill with required values
+			if err := controllerutil.SetControllerReference(plant, object, m.Client().Scheme()); err != nil {
+				return err
+			}
+			return m.Client().Create(ctx, object)
+		},
+		UpdateFunc: func(ctx context.Context, object *rbacv1.RoleBinding) (bool, error) {
+			// RoleRef is immutable, recreate the binding to reference another role
+			if expected.RoleRef != object.RoleRef {
+				if err := m.Client().Delete(ctx, object); client.IgnoreNotFound(err) != nil {
+					return false, err
+				}
+				*object = rbacv1.RoleBinding{}
+				expected.DeepCopyInto(object)
+				if err := controllerutil.SetControllerReference(plant, object, m.Client().Scheme()); err != nil {
+					return false, err
+				}
+				return true, m.Client().Create(ctx, object)
+			}
+			if !equality.Semantic.DeepEqual(expected.Subjects, object.Subjects) {
+				object.Subjects = expected.Subjects
+				utils.MergeMapsSrcDst(expected.Labels, object.Labels)
+				return true, m.Client().Update(ctx, object)
+			}
+			return false, nil
+		},
+		IsReady: func(_ context.Context, object *rbacv1.RoleBinding) bool {
+			return true
+		},
+	}
+}
+
+func defineServiceAccount(plant *apiv1.Plant) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        plant.GetServiceAccountName(),
+			Namespace:   plant.Namespace,
+			Labels:      plant.OperatorLabels(),
+			Annotations: withManagedAnnotations(plant.Spec.ServiceAccount.Annotations),
+		},
+		AutomountServiceAccountToken: plant.Spec.ServiceAccount.AutomountToken,
+	}
+}
+
+func defineRole(plant *apiv1.Plant) *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      plant.Name,
+			Namespace: plant.Namespace,
+			Labels:    plant.OperatorLabels(),
+		},
+		Rules: plant.Spec.ServiceAccount.Rules,
+	}
+}
+
+// defineRoleBinding binds the managed ServiceAccount to the referenced role, or to the Role
+// named after Plant if rules are specified
+func defineRoleBinding(plant *apiv1.Plant) *rbacv1.RoleBinding {
+	kind, name := "Role", plant.Name
+	if ref := plant.Spec.ServiceAccount.RoleRef; ref != nil {
+		name = ref.Name
+		if ref.Kind != "" {
+			kind = ref.Kind
+		}
+	}
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      plant.Name,
+			Namespace: plant.Namespace,
+			Labels:    plant.OperatorLabels(),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     kind,
+			Name:     name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      plant.GetServiceAccountName(),
+				Namespace: plant.Namespace,
+			},
+		},
+	}
+}
+
+// roleRequired returns true if Plant declares permissions for its managed ServiceAccount
+func roleRequired(plant *apiv1.Plant) bool {
+	account := plant.Spec.ServiceAccount
+	return account != nil && account.Create && len(account.Rules) > 0
+}
+
+// roleBindingRequired returns true if Plant declares permissions or references a role for
+// its managed ServiceAccount
+func roleBindingRequired(plant *apiv1.Plant) bool {
+	account := plant.Spec.ServiceAccount
+	return account != nil && account.Create && (len(account.Rules) > 0 || account.RoleRef != nil)
+}
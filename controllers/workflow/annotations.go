@@ -0,0 +1,197 @@
+package workflow
+
+import (
+	"fmt"
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"github.com/fhivemind/plant-operator/pkg/ingress"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ingressAnnotations defines annotations of Ingress resources and the Service translated for
+// the Ingress class of Plant
+type ingressAnnotations struct {
+	ingress  map[string]string
+	service  map[string]string
+	redirect map[string]string
+	canary   map[string]string
+	variant  map[string]string
+}
+
+// translateIngressAnnotations translates requested routing features for the Ingress class
+// of Plant. Returns empty annotations in other routing modes, or if no routing features are
+// requested, so that unset Ingress classes and classes without registered translator receive
+// no annotations.
+func translateIngressAnnotations(plant *apiv1.Plant) (ingressAnnotations, error) {
+	var annotations ingressAnnotations
+	if plant.GetRoutingMode() != apiv1.RoutingModeIngress || (plant.Spec.IngressOptions == nil &&
+		!plant.Spec.RedirectToHost && !plant.CanaryActive() && !plant.VariantActive()) {
+		return annotations, nil
+	}
+	translator, err := ingress.ForClass(plant.Spec.IngressClassName)
+	if err != nil {
+		return annotations, err
+	}
+
+	// Translate options
+	options, err := translator.OptionAnnotations(ingressOptions(plant))
+	if err != nil {
+		return annotations, err
+	}
+	annotations.ingress, annotations.service = options.Ingress, options.Service
+
+	// Translate other Ingress resources
+	if plant.Spec.RedirectToHost {
+		scheme := "http"
+		if plant.Spec.TlsSecretName != nil || plant.Spec.TlsCertIssuerRef != nil {
+			scheme = "https"
+		}
+		if annotations.redirect, err = translator.RedirectAnnotations(scheme, plant.Spec.Host); err != nil {
+			return annotations, err
+		}
+	}
+	if plant.CanaryActive() {
+		if annotations.canary, err = translator.WeightAnnotations(plant.Status.Release.Weight); err != nil {
+			return annotations, err
+		}
+	}
+	if plant.VariantActive() {
+		if annotations.variant, err = translator.MatchAnnotations(ingressMatches(plant)); err != nil {
+			return annotations, err
+		}
+	}
+	return annotations, nil
+}
+
+// ValidateIngressClass checks that the Ingress class of Plant has a registered translator which can
+// express requested redirects, canary releases, routing matches and ingress options. It is used by
+// the Plant webhook as apiv1.IngressClassValidator.
+func ValidateIngressClass(plant *apiv1.Plant) error {
+	for _, feature := range []struct {
+		field     string
+		requested bool
+		translate func(translator ingress.Translator) error
+	}{
+		{".spec.redirectToHost", plant.Spec.RedirectToHost, func(translator ingress.Translator) error {
+			_, err := translator.RedirectAnnotations("https", plant.Spec.Host)
+			return err
+		}},
+		{".spec.release.canary", plant.Spec.Release != nil && plant.Spec.Release.Canary != nil, func(translator ingress.Translator) error {
+			_, err := translator.WeightAnnotations(0)
+			return err
+		}},
+		{".spec.routing.matches", plant.Spec.Routing != nil && len(plant.Spec.Routing.Matches) > 0, func(translator ingress.Translator) error {
+			_, err := translator.MatchAnnotations(ingressMatches(plant))
+			return err
+		}},
+		{".spec.ingressOptions", plant.Spec.IngressOptions != nil, func(translator ingress.Translator) error {
+			_, err := translator.OptionAnnotations(ingressOptions(plant))
+			return err
+		}},
+	} {
+		if !feature.requested {
+			continue
+		}
+		translator, err := ingress.ForClass(plant.Spec.IngressClassName)
+		if err != nil {
+			return fmt.Errorf("%s requires .spec.ingressClassName with known annotations in Ingress routing mode: %w", feature.field, err)
+		}
+		if err := feature.translate(translator); err != nil {
+			return fmt.Errorf("%s cannot be expressed by %s: %w", feature.field, translator.Controller(), err)
+		}
+	}
+	return nil
+}
+
+// ingressMatches returns routing matches of Plant for ingress.Translator
+func ingressMatches(plant *apiv1.Plant) []ingress.Match {
+	if plant.Spec.Routing == nil {
+		return nil
+	}
+	matches := make([]ingress.Match, 0, len(plant.Spec.Routing.Matches))
+	for _, match := range plant.Spec.Routing.Matches {
+		matches = append(matches, ingress.Match{Type: ingress.MatchType(match.Type), Name: match.Name, Value: match.Value})
+	}
+	return matches
+}
+
+// ingressOptions returns ingress options of Plant for ingress.Translator
+func ingressOptions(plant *apiv1.Plant) ingress.Options {
+	config := plant.Spec.IngressOptions
+	if config == nil {
+		return ingress.Options{}
+	}
+	options := ingress.Options{
+		HTTPSRedirect:   config.HTTPSRedirect,
+		BackendProtocol: ingress.Protocol(config.BackendProtocol),
+	}
+	if config.MaxBodySize != nil {
+		size := config.MaxBodySize.Value()
+		options.MaxBodySize = &size
+	}
+	if timeouts := config.Timeouts; timeouts != nil {
+		options.ConnectTimeout = durationOrNil(timeouts.Connect)
+		options.ReadTimeout = durationOrNil(timeouts.Read)
+		options.SendTimeout = durationOrNil(timeouts.Send)
+	}
+	if cors := config.CORS; cors != nil {
+		options.CORS = &ingress.CORS{
+			AllowOrigins:     cors.AllowOrigins,
+			AllowMethods:     cors.AllowMethods,
+			AllowHeaders:     cors.AllowHeaders,
+			AllowCredentials: cors.AllowCredentials,
+			MaxAge:           durationOrNil(cors.MaxAge),
+		}
+	}
+	if limit := config.RateLimit; limit != nil {
+		options.RateLimit = &ingress.RateLimit{RequestsPerSecond: limit.RequestsPerSecond, Connections: limit.Connections}
+	}
+	return options
+}
+
+// durationOrNil returns the value of duration, or nil if not set
+func durationOrNil(duration *metav1.Duration) *time.Duration {
+	if duration == nil {
+		return nil
+	}
+	return &duration.Duration
+}
+
+// withManagedAnnotations returns a copy of all managed annotations merged in order, and records
+// their keys with apiv1.ManagedAnnotationsAnnotation so that they can be removed once no longer managed.
+func withManagedAnnotations(managed ...map[string]string) map[string]string {
+	result := make(map[string]string)
+	for _, annotations := range managed {
+		for key, value := range annotations {
+			result[key] = value
+		}
+	}
+	keys := make([]string, 0, len(result))
+	for key := range result {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result[apiv1.ManagedAnnotationsAnnotation] = strings.Join(keys, ",")
+	return result
+}
+
+// pruneManagedAnnotations removes annotations recorded as managed on object which are not expected.
+// Returns true if any annotation was removed.
+func pruneManagedAnnotations(expected, object map[string]string) bool {
+	managed, ok := object[apiv1.ManagedAnnotationsAnnotation]
+	if !ok || managed == "" {
+		return false
+	}
+	pruned := false
+	for _, key := range strings.Split(managed, ",") {
+		if _, ok := expected[key]; !ok {
+			if _, ok := object[key]; ok {
+				delete(object, key)
+				pruned = true
+			}
+		}
+	}
+	return pruned
+}
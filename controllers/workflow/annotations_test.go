@@ -0,0 +1,61 @@
+package workflow_test
+
+import (
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"github.com/fhivemind/plant-operator/controllers/workflow"
+	"github.com/fhivemind/plant-operator/pkg/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ingress class validation", func() {
+	It("Should reject routing matches which cannot be expressed", func() {
+		plant := &apiv1.Plant{Spec: apiv1.PlantSpec{Host: "example.com", Routing: &apiv1.Routing{
+			Matches:      []apiv1.RouteMatch{{Type: apiv1.RouteMatchHeader, Name: "X-Variant", Value: "b"}},
+			VariantImage: "nginx:1.23",
+		}}}
+		Expect(workflow.ValidateIngressClass(plant)).To(MatchError(ContainSubstring(".spec.routing.matches requires .spec.ingressClassName")))
+
+		plant.Spec.IngressClassName = utils.Pointer("nginx")
+		Expect(workflow.ValidateIngressClass(plant)).To(Succeed())
+
+		plant.Spec.Routing.Matches = append(plant.Spec.Routing.Matches, apiv1.RouteMatch{Type: apiv1.RouteMatchQueryParam, Name: "variant", Value: "b"})
+		Expect(workflow.ValidateIngressClass(plant)).To(MatchError(ContainSubstring(".spec.routing.matches cannot be expressed by ingress-nginx")))
+
+		plant.Spec.IngressClassName = utils.Pointer("unknown")
+		Expect(workflow.ValidateIngressClass(plant)).To(MatchError(ContainSubstring("no translator registered for ingress class \"unknown\"")))
+	})
+
+	It("Should reject ingress options which cannot be expressed", func() {
+		plant := &apiv1.Plant{Spec: apiv1.PlantSpec{Host: "example.com", IngressOptions: &apiv1.IngressOptions{
+			HTTPSRedirect:   utils.Pointer(true),
+			BackendProtocol: apiv1.BackendProtocolGRPC,
+		}}}
+		Expect(workflow.ValidateIngressClass(plant)).To(MatchError(ContainSubstring(".spec.ingressOptions requires .spec.ingressClassName")))
+
+		plant.Spec.IngressClassName = utils.Pointer("nginx")
+		Expect(workflow.ValidateIngressClass(plant)).To(Succeed())
+
+		plant.Spec.IngressClassName = utils.Pointer("traefik")
+		Expect(workflow.ValidateIngressClass(plant)).To(MatchError(ContainSubstring(".spec.ingressOptions cannot be expressed by traefik")))
+
+		plant.Spec.IngressOptions.HTTPSRedirect = nil
+		Expect(workflow.ValidateIngressClass(plant)).To(Succeed())
+	})
+
+	It("Should reject redirects and canary releases which the Ingress class cannot express", func() {
+		plant := &apiv1.Plant{Spec: apiv1.PlantSpec{Host: "example.com", IngressClassName: utils.Pointer("traefik")}}
+		plant.Spec.RedirectToHost = true
+		Expect(workflow.ValidateIngressClass(plant)).To(MatchError(ContainSubstring(".spec.redirectToHost cannot be expressed by traefik")))
+
+		plant.Spec.RedirectToHost = false
+		plant.Spec.Release = &apiv1.Release{Canary: &apiv1.CanaryRelease{Steps: []apiv1.CanaryStep{{Weight: 10}}}}
+		Expect(workflow.ValidateIngressClass(plant)).To(MatchError(ContainSubstring(".spec.release.canary cannot be expressed by traefik")))
+
+		plant.Spec.IngressClassName = utils.Pointer("unknown")
+		Expect(workflow.ValidateIngressClass(plant)).To(MatchError(ContainSubstring(".spec.release.canary requires .spec.ingressClassName")))
+
+		plant.Spec.IngressClassName = utils.Pointer("nginx")
+		Expect(workflow.ValidateIngressClass(plant)).To(Succeed())
+	})
+})
@@ -0,0 +1,104 @@
+package workflow
+
+import (
+	"context"
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"github.com/fhivemind/plant-operator/pkg/resource"
+	"github.com/fhivemind/plant-operator/pkg/utils"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// newAutoscalerOrRemoveHandler creates either a HorizontalPodAutoscaler resource.Executor or a
+// resource.RemoveExecutor depending on the state of Plant.
+func (m *manager) newAutoscalerOrRemoveHandler(plant *apiv1.Plant) resource.Executor[*autoscalingv2.HorizontalPodAutoscaler] {
+	if plant.Spec.Autoscaling == nil {
+		return newRemoveHandler[*autoscalingv2.HorizontalPodAutoscaler](m, "HorizontalPodAutoscaler", plant, plant.Name)
+	}
+
+	// Create expected object
+	expected := defineAutoscaler(plant)
+	m.Client().Scheme().Default(expected)
+
+	// Return handler
+	return resource.Executor[*autoscalingv2.HorizontalPodAutoscaler]{
+		Name: "HorizontalPodAutoscaler",
+		FetchFunc: func(ctx context.Context, object *autoscalingv2.HorizontalPodAutoscaler) error {
+			return m.Client().Get(ctx, types.NamespacedName{Namespace: expected.Namespace, Name: expected.Name}, object)
+		},
+		CreateFunc: func(ctx context.Context, object *autoscalingv2.HorizontalPodAutoscaler) error {
+			expected.DeepCopyInto(object) // fill with required values
+			if err := controllerutil.SetControllerReference(plant, object, m.Client().Scheme()); err != nil {
+				return err
+			}
+			return m.Client().Create(ctx, object)
+		},
+		UpdateFunc: func(ctx context.Context, object *autoscalingv2.HorizontalPodAutoscaler) (bool, error) {
+			diff := utils.Diff(&expected.Spec, &object.Spec)
+			metricsChanged := !equality.Semantic.DeepEqual(expected.Spec.Metrics, object.Spec.Metrics)
+			if diff.NotEqual() || metricsChanged {
+				object.Spec.ScaleTargetRef = expected.Spec.ScaleTargetRef
+				object.Spec.MinReplicas = expected.Spec.MinReplicas
+				object.Spec.MaxReplicas = expected.Spec.MaxReplicas
+				object.Spec.Metrics = expected.Spec.Metrics
+				utils.MergeMapsSrcDst(expected.Labels, object.Labels)
+				return true, m.Client().Update(ctx, object)
+			}
+			return false, diff.Error()
+		},
+		IsReady: func(_ context.Context, object *autoscalingv2.HorizontalPodAutoscaler) bool {
+			for _, condition := range object.Status.Conditions {
+				if condition.Type == autoscalingv2.AbleToScale && condition.Status == corev1.ConditionFalse {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+func defineAutoscaler(plant *apiv1.Plant) *autoscalingv2.HorizontalPodAutoscaler {
+	// Defaults
+	autoscaling := plant.Spec.Autoscaling
+	var metrics []autoscalingv2.MetricSpec
+	addUtilizationMetric := func(name corev1.ResourceName, target *int32) {
+		if target == nil {
+			return
+		}
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: name,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: target,
+				},
+			},
+		})
+	}
+	addUtilizationMetric(corev1.ResourceCPU, autoscaling.TargetCPUUtilizationPercentage)
+	addUtilizationMetric(corev1.ResourceMemory, autoscaling.TargetMemoryUtilizationPercentage)
+
+	// Return HorizontalPodAutoscaler
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      plant.Name,
+			Namespace: plant.Namespace,
+			Labels:    plant.OperatorLabels(),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       plant.Name,
+			},
+			MinReplicas: autoscaling.MinReplicas,
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package workflow
+
+import (
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"github.com/fhivemind/plant-operator/pkg/resource"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newCanaryDeploymentOrRemoveHandler creates either a canary Deployment resource.Executor or a
+// resource.RemoveExecutor depending on the release state of Plant.
+func (m *manager) newCanaryDeploymentOrRemoveHandler(plant *apiv1.Plant, configHash string) resource.Executor[*appsv1.Deployment] {
+	if !plant.CanaryActive() {
+		return newRemoveHandler[*appsv1.Deployment](m, "CanaryDeployment", plant, plant.CanaryName())
+	}
+	return m.newDeploymentExecutor("CanaryDeployment", plant, defineCanaryDeployment(plant, configHash), false)
+}
+
+// newCanaryServiceOrRemoveHandler creates either a canary Service resource.Executor or a
+// resource.RemoveExecutor depending on the release state of Plant.
+func (m *manager) newCanaryServiceOrRemoveHandler(plant *apiv1.Plant) resource.Executor[*corev1.Service] {
+	if !plant.CanaryActive() {
+		return newRemoveHandler[*corev1.Service](m, "CanaryService", plant, plant.CanaryName())
+	}
+	return m.newServiceExecutor("CanaryService", plant, defineCanaryService(plant))
+}
+
+// newCanaryIngressOrRemoveHandler creates either a canary Ingress resource.Executor or a
+// resource.RemoveExecutor depending on the release state and routing mode of Plant.
+// Canary Ingress splits traffic using annotations translated for the Ingress class.
+func (m *manager) newCanaryIngressOrRemoveHandler(plant *apiv1.Plant, tlsSecretName *string, annotations map[string]string) resource.Executor[*networkingv1.Ingress] {
+	if !plant.CanaryActive() || plant.GetRoutingMode() != apiv1.RoutingModeIngress {
+		return newRemoveHandler[*networkingv1.Ingress](m, "CanaryIngress", plant, plant.CanaryName())
+	}
+	return m.newIngressExecutor("CanaryIngress", plant, defineCanaryIngress(plant, tlsSecretName, annotations))
+}
+
+// defineCanaryDeployment defines a Deployment of the released image with pods selected by
+// canary labels. Replicas are scaled to the traffic share of the stable Deployment.
+func defineCanaryDeployment(plant *apiv1.Plant, configHash string) *appsv1.Deployment {
+	// Defaults
+	replicas := (plant.GetMinReplicas()*plant.Status.Release.Weight + 99) / 100
+	if replicas < 1 {
+		replicas = 1
+	}
+
+	// Return Deployment
+	deployment := defineDeployment(plant, configHash)
+	deployment.Name = plant.CanaryName()
+	deployment.Spec.Replicas = &replicas
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: plant.CanaryLabels()}
+	deployment.Spec.Template.Labels = plant.CanaryLabels()
+	deployment.Spec.Template.Spec.Containers[0].Image = plant.Status.Release.CanaryImage
+	return deployment
+}
+
+// defineCanaryService defines an internal Service exposing canary pods
+func defineCanaryService(plant *apiv1.Plant) *corev1.Service {
+	service := defineService(plant)
+	service.Name = plant.CanaryName()
+	service.Annotations = nil
+	service.Spec.Selector = plant.CanaryLabels()
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	service.Spec.ExternalTrafficPolicy = ""
+	return service
+}
+
+// defineCanaryIngress defines a canary Ingress for the Plant host which sends the
+// current release weight to the canary Service as defined by annotations
+func defineCanaryIngress(plant *apiv1.Plant, tlsSecretName *string, annotations map[string]string) *networkingv1.Ingress {
+	ingress := defineIngress(plant, tlsSecretName)
+	ingress.Name = plant.CanaryName()
+	ingress.Annotations = annotations
+	for _, rule := range ingress.Spec.Rules {
+		for i := range rule.HTTP.Paths {
+			rule.HTTP.Paths[i].Backend.Service.Name = plant.CanaryName()
+		}
+	}
+	return ingress
+}
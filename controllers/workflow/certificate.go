@@ -10,6 +10,7 @@ import (
 	"github.com/fhivemind/plant-operator/pkg/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -44,7 +45,8 @@ func (m *manager) newTlsOrNopHandler(plant *apiv1.Plant) (*string, resource.Exec
 		},
 		UpdateFunc: func(ctx context.Context, object *certv1.Certificate) (bool, error) {
 			diff := utils.Diff(&expected.Spec, &object.Spec)
-			if diff.NotEqual() {
+			dnsNamesChanged := !reflect.DeepEqual(expected.Spec.DNSNames, object.Spec.DNSNames)
+			if diff.NotEqual() || dnsNamesChanged {
 				expected.Spec.DeepCopyInto(&object.Spec)
 				utils.MergeMapsSrcDst(expected.Labels, object.Labels)
 				return true, m.Client().Update(ctx, object)
@@ -78,7 +80,7 @@ func defineOrSkipCertificate(plant *apiv1.Plant) *certv1.Certificate {
 		},
 		Spec: certv1.CertificateSpec{
 			SecretName: fmt.Sprintf("%s-tls", plant.Name),
-			DNSNames:   []string{plant.Spec.Host},
+			DNSNames:   plant.GetHosts(),
 			IssuerRef:  *plant.Spec.TlsCertIssuerRef,
 		},
 	}
@@ -0,0 +1,133 @@
+package workflow
+
+import (
+	"context"
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"github.com/fhivemind/plant-operator/pkg/resource"
+	"github.com/fhivemind/plant-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// newClaimOrRemoveHandler creates either a PersistentVolumeClaim resource.Executor or a
+// resource.RemoveExecutor depending on the state of Plant.
+// Claims with RetainOnDelete are not controlled by Plant, so they will not be
+// garbage collected or removed.
+func (m *manager) newClaimOrRemoveHandler(plant *apiv1.Plant) resource.Executor[*corev1.PersistentVolumeClaim] {
+	volume := plant.GetManagedClaim()
+	if volume == nil {
+		return newRemoveHandler[*corev1.PersistentVolumeClaim](m, "PersistentVolumeClaim", plant, plant.Name)
+	}
+
+	// Create expected object
+	expected := defineClaim(plant, volume.ManagedClaim)
+	m.Client().Scheme().Default(expected)
+	retain := volume.ManagedClaim.RetainOnDelete
+
+	// Return handler
+	return resource.Executor[*corev1.PersistentVolumeClaim]{
+		Name: "PersistentVolumeClaim",
+		FetchFunc: func(ctx context.Context, object *corev1.PersistentVolumeClaim) error {
+			return m.Client().Get(ctx, types.NamespacedName{Namespace: expected.Namespace, Name: expected.Name}, object)
+		},
+		CreateFunc: func(ctx context.Context, object *corev1.PersistentVolumeClaim) error {
+			expected.DeepCopyInto(object) // fill with required values
+			if !retain {
+				if err := controllerutil.SetControllerReference(plant, object, m.Client().Scheme()); err != nil {
+					return err
+				}
+			}
+			return m.Client().Create(ctx, object)
+		},
+		UpdateFunc: func(ctx context.Context, object *corev1.PersistentVolumeClaim) (bool, error) {
+			// Claim spec is immutable except for storage expansion
+			expectedSize := expected.Spec.Resources.Requests[corev1.ResourceStorage]
+			currentSize := object.Spec.Resources.Requests[corev1.ResourceStorage]
+			sizeIncreased := expectedSize.Cmp(currentSize) > 0
+			controlled := metav1.IsControlledBy(object, plant)
+			ownerChanged := retain == controlled
+			labelsChanged := !utils.MapContains(object.Labels, expected.Labels)
+			if !sizeIncreased && !ownerChanged && !labelsChanged {
+				return false, nil
+			}
+
+			if sizeIncreased {
+				if object.Spec.Resources.Requests == nil {
+					object.Spec.Resources.Requests = make(corev1.ResourceList)
+				}
+				object.Spec.Resources.Requests[corev1.ResourceStorage] = expectedSize
+			}
+			if ownerChanged && retain {
+				object.OwnerReferences = removeOwnerReference(object.OwnerReferences, plant.UID)
+			} else if ownerChanged {
+				if err := controllerutil.SetControllerReference(plant, object, m.Client().Scheme()); err != nil {
+					return false, err
+				}
+			}
+			if object.Labels == nil {
+				object.Labels = make(map[string]string)
+			}
+			utils.MergeMapsSrcDst(expected.Labels, object.Labels)
+			return true, m.Client().Update(ctx, object)
+		},
+		IsReady: func(ctx context.Context, object *corev1.PersistentVolumeClaim) bool {
+			switch object.Status.Phase {
+			case corev1.ClaimBound:
+				return true
+			case corev1.ClaimPending, "": // phase is not set until claim is processed
+				return m.waitsForFirstConsumer(ctx, object)
+			}
+			return false
+		},
+	}
+}
+
+// waitsForFirstConsumer checks if the claim is bound only once a pod using it is scheduled
+func (m *manager) waitsForFirstConsumer(ctx context.Context, claim *corev1.PersistentVolumeClaim) bool {
+	if claim.Spec.StorageClassName == nil || *claim.Spec.StorageClassName == "" {
+		return false
+	}
+	storageClass := &storagev1.StorageClass{}
+	if err := m.Client().Get(ctx, types.NamespacedName{Name: *claim.Spec.StorageClassName}, storageClass); err != nil {
+		return false
+	}
+	return storageClass.VolumeBindingMode != nil && *storageClass.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer
+}
+
+func defineClaim(plant *apiv1.Plant, claim *apiv1.ManagedClaim) *corev1.PersistentVolumeClaim {
+	// Defaults
+	accessModes := claim.AccessModes
+	if len(accessModes) == 0 {
+		accessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+
+	// Return PersistentVolumeClaim
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      plant.Name,
+			Namespace: plant.Namespace,
+			Labels:    plant.OperatorLabels(),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      accessModes,
+			StorageClassName: claim.StorageClassName,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: claim.Size},
+			},
+		},
+	}
+}
+
+// removeOwnerReference returns references without the owner with given UID
+func removeOwnerReference(references []metav1.OwnerReference, uid types.UID) []metav1.OwnerReference {
+	result := make([]metav1.OwnerReference, 0, len(references))
+	for _, reference := range references {
+		if reference.UID != uid {
+			result = append(result, reference)
+		}
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// configHash computes a hash over the content of all ConfigMaps and Secrets referenced by Plant.
+// Missing objects are skipped as they can be optional, and will be picked up once created.
+// Returns an empty string if Plant does not reference any configuration.
+func (m *manager) configHash(ctx context.Context, plant *apiv1.Plant) (string, error) {
+	configMapNames, secretNames := plant.ReferencedConfigMaps(), plant.ReferencedSecrets()
+	if len(configMapNames) == 0 && len(secretNames) == 0 {
+		return "", nil
+	}
+
+	hash := sha256.New()
+	for _, name := range configMapNames {
+		configMap := &corev1.ConfigMap{}
+		if found, err := m.fetchOptional(ctx, plant.Namespace, name, configMap); err != nil {
+			return "", err
+		} else if !found {
+			continue
+		}
+		if err := writeHash(hash, "ConfigMap", name, configMap.Data, configMap.BinaryData); err != nil {
+			return "", err
+		}
+	}
+	for _, name := range secretNames {
+		secret := &corev1.Secret{}
+		if found, err := m.fetchOptional(ctx, plant.Namespace, name, secret); err != nil {
+			return "", err
+		} else if !found {
+			continue
+		}
+		if err := writeHash(hash, "Secret", name, secret.Data); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// fetchOptional fetches the object and returns false if it does not exist.
+// Uses the configured config reader if set.
+func (m *manager) fetchOptional(ctx context.Context, namespace, name string, object client.Object) (bool, error) {
+	var reader client.Reader = m.Client()
+	if m.configReader != nil {
+		reader = m.configReader
+	}
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, object); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return true, nil
+}
+
+// writeHash writes object identity and data to hash. Maps are encoded with sorted keys.
+func writeHash(hash io.Writer, kind, name string, data ...interface{}) error {
+	encoded, err := json.Marshal(append([]interface{}{kind, name}, data...))
+	if err != nil {
+		return err
+	}
+	_, err = hash.Write(encoded)
+	return err
+}
@@ -0,0 +1,98 @@
+package workflow
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// defineContainers copies additional Plant containers with probe thresholds set explicitly, since
+// containersChanged cannot tell them apart from API server defaults.
+func defineContainers(containers []corev1.Container) []corev1.Container {
+	if len(containers) == 0 {
+		return nil
+	}
+	result := make([]corev1.Container, 0, len(containers))
+	for _, container := range containers {
+		container := *container.DeepCopy()
+		for _, probe := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe, container.StartupProbe} {
+			defaultContainerProbe(probe)
+		}
+		result = append(result, container)
+	}
+	return result
+}
+
+// containersChanged checks if received containers differ from expected ones. Only fields set on
+// expected containers are compared, so that API server defaults do not cause updates. Removed list
+// entries and handlers are checked separately since they cannot be detected this way.
+func containersChanged(expected, received []corev1.Container) bool {
+	if len(expected) != len(received) {
+		return true
+	}
+	for i := range expected {
+		expectedContainer, receivedContainer := &expected[i], &received[i]
+		if !equality.Semantic.DeepDerivative(*expectedContainer, *receivedContainer) ||
+			expectedContainer.Name != receivedContainer.Name ||
+			expectedContainer.WorkingDir != receivedContainer.WorkingDir ||
+			len(expectedContainer.Command) != len(receivedContainer.Command) ||
+			len(expectedContainer.Args) != len(receivedContainer.Args) ||
+			len(expectedContainer.Env) != len(receivedContainer.Env) ||
+			len(expectedContainer.EnvFrom) != len(receivedContainer.EnvFrom) ||
+			len(expectedContainer.Ports) != len(receivedContainer.Ports) ||
+			len(expectedContainer.VolumeMounts) != len(receivedContainer.VolumeMounts) ||
+			(expectedContainer.LivenessProbe == nil) != (receivedContainer.LivenessProbe == nil) ||
+			(expectedContainer.ReadinessProbe == nil) != (receivedContainer.ReadinessProbe == nil) ||
+			(expectedContainer.StartupProbe == nil) != (receivedContainer.StartupProbe == nil) ||
+			(expectedContainer.Lifecycle == nil) != (receivedContainer.Lifecycle == nil) ||
+			(expectedContainer.SecurityContext == nil) != (receivedContainer.SecurityContext == nil) {
+			return true
+		}
+	}
+	return false
+}
+
+// withSecurityContext sets a copy of securityContext on containers without own security context
+func withSecurityContext(containers []corev1.Container, securityContext *corev1.SecurityContext) []corev1.Container {
+	if securityContext == nil {
+		return containers
+	}
+	for i := range containers {
+		if containers[i].SecurityContext == nil {
+			containers[i].SecurityContext = securityContext.DeepCopy()
+		}
+	}
+	return containers
+}
+
+// defaultContainerProbe sets API server defaults on the probe
+func defaultContainerProbe(probe *corev1.Probe) {
+	if probe == nil {
+		return
+	}
+	if probe.TimeoutSeconds == 0 {
+		probe.TimeoutSeconds = 1
+	}
+	if probe.PeriodSeconds == 0 {
+		probe.PeriodSeconds = 10
+	}
+	if probe.SuccessThreshold == 0 {
+		probe.SuccessThreshold = 1
+	}
+	if probe.FailureThreshold == 0 {
+		probe.FailureThreshold = 3
+	}
+	defaultHTTPGetAction(probe.HTTPGet)
+}
+
+// defaultHTTPGetAction sets API server defaults on the action
+func defaultHTTPGetAction(action *corev1.HTTPGetAction) {
+	if action == nil {
+		return
+	}
+	if action.Path == "" {
+		action.Path = "/"
+	}
+	if action.Scheme == "" {
+		action.Scheme = corev1.URISchemeHTTP
+	}
+}
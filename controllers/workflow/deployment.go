@@ -2,25 +2,50 @@ package workflow
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	apiv1 "github.com/fhivemind/plant-operator/api/v1"
 	"github.com/fhivemind/plant-operator/pkg/resource"
 	"github.com/fhivemind/plant-operator/pkg/utils"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-// newDeploymentHandler creates deployment resource.Executor for the given Plant
-func (m *manager) newDeploymentHandler(plant *apiv1.Plant) resource.Executor[*appsv1.Deployment] {
-	// Create expected object
-	expected := defineDeployment(plant)
+// deploymentProgressDeadlineExceeded defines the Progressing condition reason of stuck rollouts
+const deploymentProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+// ProgressDeadlineExceededErr indicates that the Deployment rollout is stuck
+var ProgressDeadlineExceededErr = errors.New("deployment rollout exceeded its progress deadline")
+
+// newDeploymentHandler creates deployment resource.Executor for the given Plant.
+// It also requires a configHash of referenced configuration which will be added
+// to pod template annotations to trigger rollouts on configuration changes.
+// If empty, the annotation will not be added. The pod template is kept while rolled back
+// on request, or while Plant defines the pod template of a rollout which was rolled back.
+func (m *manager) newDeploymentHandler(plant *apiv1.Plant, configHash string) resource.Executor[*appsv1.Deployment] {
+	expected := defineDeployment(plant, configHash)
+	templateHash := PodTemplateHash(expected)
+	expected.Annotations = map[string]string{apiv1.TemplateHashAnnotation: templateHash}
+	keepRevision := plant.Spec.RollbackTo != nil ||
+		(plant.Status.Rollback != nil && plant.Status.Rollback.FailedTemplateHash == templateHash)
+	return m.newDeploymentExecutor("Deployment", plant, expected, keepRevision)
+}
+
+// newDeploymentExecutor creates resource.Executor for the expected Deployment.
+// Pod template updates are skipped if keepRevision is set, e.g. to keep a rolled back revision.
+// Replicas are preserved if not specified by the expected Deployment, e.g. when
+// managed by HorizontalPodAutoscaler.
+func (m *manager) newDeploymentExecutor(name string, plant *apiv1.Plant, expected *appsv1.Deployment, keepRevision bool) resource.Executor[*appsv1.Deployment] {
 	m.Client().Scheme().Default(expected)
 
 	// Return handler
 	return resource.Executor[*appsv1.Deployment]{
-		Name: "Deployment",
+		Name: name,
 		FetchFunc: func(ctx context.Context, object *appsv1.Deployment) error {
 			return m.Client().Get(ctx, types.NamespacedName{Namespace: expected.Namespace, Name: expected.Name}, object)
 		},
@@ -32,29 +57,148 @@ func (m *manager) newDeploymentHandler(plant *apiv1.Plant) resource.Executor[*ap
 			return m.Client().Create(ctx, object)
 		},
 		UpdateFunc: func(ctx context.Context, object *appsv1.Deployment) (bool, error) {
+			if keepRevision {
+				object.Spec.Template.DeepCopyInto(&expected.Spec.Template)
+			}
 			diff := utils.Diff(&expected.Spec, &object.Spec)
-			if diff.NotEqual() {
+			podSpecChanged := podSpecChanged(&expected.Spec.Template.Spec, &object.Spec.Template.Spec)
+			strategyChanged := !equality.Semantic.DeepEqual(expected.Spec.Strategy, object.Spec.Strategy)
+			configHashChanged := expected.Spec.Template.Annotations[apiv1.ConfigHashAnnotation] !=
+				object.Spec.Template.Annotations[apiv1.ConfigHashAnnotation] // also detects removed hash
+			annotationsChanged := !utils.MapContains(object.Annotations, expected.Annotations)
+			if diff.NotEqual() || podSpecChanged || strategyChanged || configHashChanged || annotationsChanged {
+				replicas := object.Spec.Replicas
 				expected.Spec.DeepCopyInto(&object.Spec)
+				if expected.Spec.Replicas == nil {
+					object.Spec.Replicas = replicas // managed by HorizontalPodAutoscaler
+				}
 				utils.MergeMapsSrcDst(expected.Labels, object.Labels)
+				if object.Annotations == nil {
+					object.Annotations = make(map[string]string)
+				}
+				utils.MergeMapsSrcDst(expected.Annotations, object.Annotations)
 				return true, m.Client().Update(ctx, object)
 			}
 			return false, diff.Error()
 		},
 		IsReady: func(_ context.Context, object *appsv1.Deployment) bool {
-			available := object.Status.AvailableReplicas
-			if plant.Spec.Replicas == nil {
-				return available == apiv1.DefaultReplicaCount
+			_, done, err := rolloutStatus(object)
+			return done && err == nil
+		},
+		StatusFunc: func(_ context.Context, object *appsv1.Deployment) error {
+			message, _, err := rolloutStatus(object)
+			if err != nil {
+				return err
 			}
-			return available == *plant.Spec.Replicas
+			return resource.NewNotReadyError(message)
 		},
 	}
 }
 
-func defineDeployment(plant *apiv1.Plant) *appsv1.Deployment {
+// rolloutStatus returns the rollout progress message and true if the rollout is complete,
+// following kubectl rollout status semantics. Returns ProgressDeadlineExceededErr if stuck.
+func rolloutStatus(object *appsv1.Deployment) (string, bool, error) {
+	if object.Generation > object.Status.ObservedGeneration {
+		return "waiting for rollout spec update to be observed", false, nil
+	}
+	for _, condition := range object.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == deploymentProgressDeadlineExceeded {
+			return "", false, fmt.Errorf("%w: %s", ProgressDeadlineExceededErr, condition.Message)
+		}
+	}
+
+	replicas := apiv1.DefaultReplicaCount
+	if object.Spec.Replicas != nil {
+		replicas = *object.Spec.Replicas
+	}
+	status := object.Status
+	switch {
+	case status.UpdatedReplicas < replicas:
+		return fmt.Sprintf("waiting for rollout to finish: %d out of %d new replicas have been updated",
+			status.UpdatedReplicas, replicas), false, nil
+
+	case status.Replicas > status.UpdatedReplicas:
+		return fmt.Sprintf("waiting for rollout to finish: %d old replicas are pending termination",
+			status.Replicas-status.UpdatedReplicas), false, nil
+
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		return fmt.Sprintf("waiting for rollout to finish: %d of %d updated replicas are available",
+			status.AvailableReplicas, status.UpdatedReplicas), false, nil
+	}
+	return "rollout completed", true, nil
+}
+
+func defineDeployment(plant *apiv1.Plant, configHash string) *appsv1.Deployment {
 	// Defaults
-	containerPort := apiv1.DefaultContainerPort
-	if plant.Spec.ContainerPort != nil {
-		containerPort = *plant.Spec.ContainerPort
+	ports := plant.GetPorts()
+	containerPorts := make([]corev1.ContainerPort, 0, len(ports))
+	for _, port := range ports {
+		containerPorts = append(containerPorts, corev1.ContainerPort{
+			Name:          port.Name,
+			ContainerPort: port.ContainerPort,
+			Protocol:      port.Protocol,
+		})
+	}
+
+	var resources corev1.ResourceRequirements
+	if plantResources := plant.GetResources(); plantResources != nil {
+		resources = *plantResources
+	}
+
+	var livenessProbe, readinessProbe, startupProbe *corev1.Probe
+	if probes := plant.Spec.Probes; probes != nil {
+		livenessProbe = defineProbe(probes.Liveness, ports[0].ContainerPort)
+		readinessProbe = defineProbe(probes.Readiness, ports[0].ContainerPort)
+		startupProbe = defineProbe(probes.Startup, ports[0].ContainerPort)
+	}
+
+	volumes := defineVolumes(plant)
+
+	var lifecycle *corev1.Lifecycle
+	if plant.Spec.Lifecycle != nil {
+		lifecycle = plant.Spec.Lifecycle.DeepCopy()
+		for _, handler := range []*corev1.LifecycleHandler{lifecycle.PostStart, lifecycle.PreStop} {
+			if handler != nil {
+				defaultHTTPGetAction(handler.HTTPGet)
+			}
+		}
+	}
+
+	podSecurityContext, securityContext := plant.GetSecurityContexts()
+	if podSecurityContext == nil {
+		podSecurityContext = &corev1.PodSecurityContext{} // match API server defaults
+	}
+	initContainers := withSecurityContext(defineContainers(plant.Spec.InitContainers), securityContext)
+	sidecars := withSecurityContext(defineContainers(plant.Spec.Sidecars), securityContext)
+
+	var automountToken *bool
+	if plant.Spec.ServiceAccount != nil {
+		automountToken = plant.Spec.ServiceAccount.AutomountToken
+	}
+
+	terminationGracePeriodSeconds := int64(corev1.DefaultTerminationGracePeriodSeconds)
+	if plant.Spec.TerminationGracePeriodSeconds != nil {
+		terminationGracePeriodSeconds = *plant.Spec.TerminationGracePeriodSeconds
+	}
+
+	var podAnnotations map[string]string
+	if configHash != "" {
+		podAnnotations = map[string]string{apiv1.ConfigHashAnnotation: configHash}
+	}
+
+	// Replicas are managed by HorizontalPodAutoscaler if enabled, and restored to the
+	// default count once disabled
+	replicas := plant.Spec.Replicas
+	if plant.Spec.Autoscaling != nil {
+		replicas = nil
+	} else if replicas == nil {
+		defaultReplicas := apiv1.DefaultReplicaCount
+		replicas = &defaultReplicas
+	}
+
+	progressDeadlineSeconds := apiv1.DefaultProgressDeadlineSeconds
+	if plant.Spec.ProgressDeadlineSeconds != nil {
+		progressDeadlineSeconds = *plant.Spec.ProgressDeadlineSeconds
 	}
 
 	// Return Deployment
@@ -68,26 +212,211 @@ func defineDeployment(plant *apiv1.Plant) *appsv1.Deployment {
 			Selector: &metav1.LabelSelector{
 				MatchLabels: plant.OperatorLabels(),
 			},
-			Replicas: plant.Spec.Replicas,
+			Replicas:                replicas,
+			Strategy:                defineStrategy(plant),
+			ProgressDeadlineSeconds: &progressDeadlineSeconds,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: plant.OperatorLabels(),
+					Labels:      plant.OperatorLabels(),
+					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{
+					ServiceAccountName:            plant.GetServiceAccountName(),
+					AutomountServiceAccountToken:  automountToken,
+					ImagePullSecrets:              plant.Spec.ImagePullSecrets,
+					Volumes:                       volumes,
+					TerminationGracePeriodSeconds: &terminationGracePeriodSeconds,
+					SecurityContext:               podSecurityContext,
+					NodeSelector:                  plant.Spec.NodeSelector,
+					Tolerations:                   plant.Spec.Tolerations,
+					Affinity:                      defineAffinity(plant),
+					TopologySpreadConstraints:     defineTopologySpreadConstraints(plant),
+					InitContainers:                initContainers,
+					Containers: append([]corev1.Container{
 						{
 							Name:            plant.Name,
-							Image:           plant.Spec.Image,
+							Image:           plant.GetStableImage(),
 							ImagePullPolicy: corev1.PullIfNotPresent,
-							Ports: []corev1.ContainerPort{
-								{
-									ContainerPort: containerPort,
-								},
-							},
+							Command:         plant.Spec.Command,
+							Args:            plant.Spec.Args,
+							WorkingDir:      plant.Spec.WorkingDir,
+							Lifecycle:       lifecycle,
+							SecurityContext: securityContext,
+							Env:             defineEnv(plant.Spec.Env),
+							EnvFrom:         plant.Spec.EnvFrom,
+							Resources:       resources,
+							LivenessProbe:   livenessProbe,
+							ReadinessProbe:  readinessProbe,
+							StartupProbe:    startupProbe,
+							Ports:           containerPorts,
+							VolumeMounts:    plant.Spec.VolumeMounts,
 						},
-					},
+					}, sidecars...),
 				},
 			},
 		},
 	}
 }
+
+// defineEnv returns a copy of env with field selector versions set explicitly to avoid
+// drifts from API server defaults.
+func defineEnv(env []corev1.EnvVar) []corev1.EnvVar {
+	if len(env) == 0 {
+		return nil
+	}
+	result := make([]corev1.EnvVar, 0, len(env))
+	for _, variable := range env {
+		variable := *variable.DeepCopy()
+		if ref := variable.ValueFrom; ref != nil && ref.FieldRef != nil && ref.FieldRef.APIVersion == "" {
+			ref.FieldRef.APIVersion = "v1"
+		}
+		result = append(result, variable)
+	}
+	return result
+}
+
+// defineStrategy converts Plant strategy to Deployment strategy with all defaults set explicitly
+// to avoid drifts from API server defaults.
+func defineStrategy(plant *apiv1.Plant) appsv1.DeploymentStrategy {
+	if plant.Spec.Strategy != nil && plant.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType {
+		return appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+	}
+	defaultValue := intstr.FromString("25%")
+	maxSurge, maxUnavailable := &defaultValue, &defaultValue
+	if plant.Spec.Strategy != nil {
+		if plant.Spec.Strategy.MaxSurge != nil {
+			maxSurge = plant.Spec.Strategy.MaxSurge
+		}
+		if plant.Spec.Strategy.MaxUnavailable != nil {
+			maxUnavailable = plant.Spec.Strategy.MaxUnavailable
+		}
+	}
+	return appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxSurge:       maxSurge,
+			MaxUnavailable: maxUnavailable,
+		},
+	}
+}
+
+// defineVolumes converts Plant volumes to pod volumes with all defaults set explicitly
+// to avoid drifts from API server defaults. ManagedClaim references the claim named after Plant.
+func defineVolumes(plant *apiv1.Plant) []corev1.Volume {
+	var volumes []corev1.Volume
+	defaultMode := corev1.ConfigMapVolumeSourceDefaultMode
+	for _, volume := range plant.Spec.Volumes {
+		source := corev1.VolumeSource{
+			EmptyDir:              volume.EmptyDir,
+			PersistentVolumeClaim: volume.PersistentVolumeClaim,
+		}
+		switch {
+		case volume.ConfigMap != nil:
+			source.ConfigMap = volume.ConfigMap.DeepCopy()
+			if source.ConfigMap.DefaultMode == nil {
+				source.ConfigMap.DefaultMode = &defaultMode
+			}
+
+		case volume.Secret != nil:
+			source.Secret = volume.Secret.DeepCopy()
+			if source.Secret.DefaultMode == nil {
+				source.Secret.DefaultMode = &defaultMode
+			}
+
+		case volume.ManagedClaim != nil:
+			source.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: plant.Name}
+		}
+		volumes = append(volumes, corev1.Volume{Name: volume.Name, VolumeSource: source})
+	}
+	return volumes
+}
+
+// defineProbe converts Plant probe to container probe with all defaults set explicitly
+// to avoid drifts from API server defaults. Probes target defaultPort unless specified.
+func defineProbe(probe *apiv1.Probe, port int32) *corev1.Probe {
+	if probe == nil {
+		return nil
+	}
+
+	// Defaults
+	defaultPort := intstr.FromInt(int(port))
+	withDefault := func(value *int32, fallback int32) int32 {
+		if value != nil {
+			return *value
+		}
+		return fallback
+	}
+
+	// Resolve handler
+	handler := corev1.ProbeHandler{}
+	switch {
+	case probe.HTTPGet != nil:
+		handler.HTTPGet = &corev1.HTTPGetAction{
+			Path:        probe.HTTPGet.Path,
+			Port:        defaultPort,
+			Scheme:      probe.HTTPGet.Scheme,
+			HTTPHeaders: probe.HTTPGet.HTTPHeaders,
+		}
+		if handler.HTTPGet.Path == "" {
+			handler.HTTPGet.Path = "/"
+		}
+		if probe.HTTPGet.Port != nil {
+			handler.HTTPGet.Port = *probe.HTTPGet.Port
+		}
+		if handler.HTTPGet.Scheme == "" {
+			handler.HTTPGet.Scheme = corev1.URISchemeHTTP
+		}
+
+	case probe.Exec != nil:
+		handler.Exec = probe.Exec
+
+	default:
+		handler.TCPSocket = &corev1.TCPSocketAction{Port: defaultPort}
+		if probe.TCPSocket != nil && probe.TCPSocket.Port != nil {
+			handler.TCPSocket.Port = *probe.TCPSocket.Port
+		}
+	}
+
+	// Return Probe
+	return &corev1.Probe{
+		ProbeHandler:        handler,
+		InitialDelaySeconds: withDefault(probe.InitialDelaySeconds, 0),
+		TimeoutSeconds:      withDefault(probe.TimeoutSeconds, 1),
+		PeriodSeconds:       withDefault(probe.PeriodSeconds, 10),
+		SuccessThreshold:    withDefault(probe.SuccessThreshold, 1),
+		FailureThreshold:    withDefault(probe.FailureThreshold, 3),
+	}
+}
+
+// podSpecChanged checks for changes which cannot be detected by utils.Diff, e.g. removed list entries.
+// Init and sidecar containers are compared with containersChanged.
+func podSpecChanged(expected, received *corev1.PodSpec) bool {
+	if len(expected.Containers) != len(received.Containers) ||
+		expected.ServiceAccountName != received.ServiceAccountName ||
+		!equality.Semantic.DeepEqual(expected.SecurityContext, received.SecurityContext) ||
+		!equality.Semantic.DeepEqual(expected.NodeSelector, received.NodeSelector) ||
+		!equality.Semantic.DeepEqual(expected.Tolerations, received.Tolerations) ||
+		!equality.Semantic.DeepEqual(expected.Affinity, received.Affinity) ||
+		!equality.Semantic.DeepEqual(expected.TopologySpreadConstraints, received.TopologySpreadConstraints) ||
+		!equality.Semantic.DeepEqual(expected.AutomountServiceAccountToken, received.AutomountServiceAccountToken) ||
+		!equality.Semantic.DeepEqual(expected.ImagePullSecrets, received.ImagePullSecrets) ||
+		!equality.Semantic.DeepEqual(expected.Volumes, received.Volumes) ||
+		containersChanged(expected.InitContainers, received.InitContainers) ||
+		containersChanged(expected.Containers[1:], received.Containers[1:]) {
+		return true
+	}
+	expectedContainer, receivedContainer := &expected.Containers[0], &received.Containers[0]
+	return !equality.Semantic.DeepEqual(expectedContainer.Command, receivedContainer.Command) ||
+		!equality.Semantic.DeepEqual(expectedContainer.Args, receivedContainer.Args) ||
+		expectedContainer.WorkingDir != receivedContainer.WorkingDir ||
+		!equality.Semantic.DeepEqual(expectedContainer.Lifecycle, receivedContainer.Lifecycle) ||
+		!equality.Semantic.DeepEqual(expectedContainer.SecurityContext, receivedContainer.SecurityContext) ||
+		!equality.Semantic.DeepEqual(expectedContainer.Ports, receivedContainer.Ports) ||
+		!equality.Semantic.DeepEqual(expectedContainer.Env, receivedContainer.Env) ||
+		!equality.Semantic.DeepEqual(expectedContainer.EnvFrom, receivedContainer.EnvFrom) ||
+		!equality.Semantic.DeepEqual(expectedContainer.Resources, receivedContainer.Resources) ||
+		!equality.Semantic.DeepEqual(expectedContainer.VolumeMounts, receivedContainer.VolumeMounts) ||
+		!equality.Semantic.DeepEqual(expectedContainer.LivenessProbe, receivedContainer.LivenessProbe) ||
+		!equality.Semantic.DeepEqual(expectedContainer.ReadinessProbe, receivedContainer.ReadinessProbe) ||
+		!equality.Semantic.DeepEqual(expectedContainer.StartupProbe, receivedContainer.StartupProbe)
+}
@@ -0,0 +1,93 @@
+package workflow
+
+import (
+	"context"
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"github.com/fhivemind/plant-operator/pkg/resource"
+	"github.com/fhivemind/plant-operator/pkg/utils"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// newDisruptionBudgetOrRemoveHandler creates either a PodDisruptionBudget resource.Executor or a
+// resource.RemoveExecutor depending on the state of Plant.
+func (m *manager) newDisruptionBudgetOrRemoveHandler(plant *apiv1.Plant) resource.Executor[*policyv1.PodDisruptionBudget] {
+	expected := defineOrSkipDisruptionBudget(plant)
+	if expected == nil {
+		return newRemoveHandler[*policyv1.PodDisruptionBudget](m, "PodDisruptionBudget", plant, plant.Name)
+	}
+	m.Client().Scheme().Default(expected)
+
+	// Return handler
+	return resource.Executor[*policyv1.PodDisruptionBudget]{
+		Name: "PodDisruptionBudget",
+		FetchFunc: func(ctx context.Context, object *policyv1.PodDisruptionBudget) error {
+			return m.Client().Get(ctx, types.NamespacedName{Namespace: expected.Namespace, Name: expected.Name}, object)
+		},
+		CreateFunc: func(ctx context.Context, object *policyv1.PodDisruptionBudget) error {
+			expected.DeepCopyInto(object) // fill with required values
+			if err := controllerutil.SetControllerReference(plant, object, m.Client().Scheme()); err != nil {
+				return err
+			}
+			return m.Client().Create(ctx, object)
+		},
+		UpdateFunc: func(ctx context.Context, object *policyv1.PodDisruptionBudget) (bool, error) {
+			diff := utils.Diff(&expected.Spec, &object.Spec)
+			minAvailableChanged := !reflect.DeepEqual(expected.Spec.MinAvailable, object.Spec.MinAvailable)
+			maxUnavailableChanged := !reflect.DeepEqual(expected.Spec.MaxUnavailable, object.Spec.MaxUnavailable)
+			if diff.NotEqual() || minAvailableChanged || maxUnavailableChanged {
+				object.Spec.Selector = expected.Spec.Selector
+				object.Spec.MinAvailable = expected.Spec.MinAvailable
+				object.Spec.MaxUnavailable = expected.Spec.MaxUnavailable
+				utils.MergeMapsSrcDst(expected.Labels, object.Labels)
+				return true, m.Client().Update(ctx, object)
+			}
+			return false, diff.Error()
+		},
+		IsReady: func(_ context.Context, object *policyv1.PodDisruptionBudget) bool {
+			// budget only limits disruptions, it does not affect availability
+			return true
+		},
+	}
+}
+
+// defineOrSkipDisruptionBudget returns PodDisruptionBudget if DisruptionBudget is specified or Plant
+// runs multiple replicas, otherwise nil.
+func defineOrSkipDisruptionBudget(plant *apiv1.Plant) *policyv1.PodDisruptionBudget {
+	// Defaults
+	budget := plant.Spec.DisruptionBudget
+	if budget == nil && plant.GetMinReplicas() <= 1 {
+		return nil
+	}
+
+	var minAvailable, maxUnavailable *intstr.IntOrString
+	switch {
+	case budget != nil && budget.MinAvailable != nil:
+		minAvailable = budget.MinAvailable
+	case budget != nil && budget.MaxUnavailable != nil:
+		maxUnavailable = budget.MaxUnavailable
+	default:
+		defaultMaxUnavailable := intstr.FromInt(1)
+		maxUnavailable = &defaultMaxUnavailable
+	}
+
+	// Return PodDisruptionBudget
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      plant.Name,
+			Namespace: plant.Namespace,
+			Labels:    plant.OperatorLabels(),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: plant.OperatorLabels(),
+			},
+			MinAvailable:   minAvailable,
+			MaxUnavailable: maxUnavailable,
+		},
+	}
+}
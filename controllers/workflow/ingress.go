@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"context"
+	"fmt"
 	apiv1 "github.com/fhivemind/plant-operator/api/v1"
 	"github.com/fhivemind/plant-operator/pkg/resource"
 	"github.com/fhivemind/plant-operator/pkg/utils"
@@ -12,18 +13,41 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-// newIngressHandler creates ingress resource.Executor for the given Plant.
-// It also requires an tlsSecretName which will be used to determine
-// if IngressTLS should be added to Ingress.
+// newIngressOrRemoveHandler creates either an ingress resource.Executor or a resource.RemoveExecutor
+// depending on the routing mode of Plant. It also requires an tlsSecretName which will be
+// used to determine if IngressTLS should be added to Ingress.
 // If nil provided, it will not use IngressTLS (insecure Ingress).
-func (m *manager) newIngressHandler(plant *apiv1.Plant, tlsSecretName *string) resource.Executor[*networkingv1.Ingress] {
-	// Create expected object
-	expected := defineIngress(plant, tlsSecretName)
+// Annotations configure ingress options for the Ingress class.
+func (m *manager) newIngressOrRemoveHandler(plant *apiv1.Plant, tlsSecretName *string, annotations map[string]string) resource.Executor[*networkingv1.Ingress] {
+	if plant.GetRoutingMode() != apiv1.RoutingModeIngress {
+		return newRemoveHandler[*networkingv1.Ingress](m, "Ingress", plant, plant.Name)
+	}
+	ingress := defineIngress(plant, tlsSecretName)
+	ingress.Annotations = annotations
+	return m.newIngressExecutor("Ingress", plant, ingress)
+}
+
+// newRedirectIngressOrRemoveHandler creates either a redirect ingress resource.Executor or a
+// resource.RemoveExecutor depending on the state of Plant.
+// Redirect Ingress handles traffic for AdditionalHosts when RedirectToHost is requested,
+// and redirects with annotations translated for the Ingress class.
+func (m *manager) newRedirectIngressOrRemoveHandler(plant *apiv1.Plant, tlsSecretName *string, annotations map[string]string) resource.Executor[*networkingv1.Ingress] {
+	expected := defineOrSkipRedirectIngress(plant, tlsSecretName, annotations)
+	if expected == nil {
+		return newRemoveHandler[*networkingv1.Ingress](m, "RedirectIngress", plant, redirectIngressName(plant))
+	}
+	return m.newIngressExecutor("RedirectIngress", plant, expected)
+}
+
+// newIngressExecutor creates resource.Executor for the expected Ingress. All expected annotations
+// are managed and are removed from the Ingress once no longer expected.
+func (m *manager) newIngressExecutor(name string, plant *apiv1.Plant, expected *networkingv1.Ingress) resource.Executor[*networkingv1.Ingress] {
 	m.Client().Scheme().Default(expected)
+	expected.Annotations = withManagedAnnotations(expected.Annotations)
 
 	// Return handler
 	return resource.Executor[*networkingv1.Ingress]{
-		Name: "Ingress",
+		Name: name,
 		FetchFunc: func(ctx context.Context, object *networkingv1.Ingress) error {
 			return m.Client().Get(ctx, types.NamespacedName{Namespace: expected.Namespace, Name: expected.Name}, object)
 		},
@@ -36,11 +60,18 @@ func (m *manager) newIngressHandler(plant *apiv1.Plant, tlsSecretName *string) r
 		},
 		UpdateFunc: func(ctx context.Context, object *networkingv1.Ingress) (bool, error) {
 			structDiff := utils.Diff(&expected.Spec, &object.Spec)
+			rulesChanged := !reflect.DeepEqual(expected.Spec.Rules, object.Spec.Rules)
 			tlsChanged := !reflect.DeepEqual(expected.Spec.TLS, object.Spec.TLS)
 			ingressClassChanged := !reflect.DeepEqual(expected.Spec.IngressClassName, object.Spec.IngressClassName)
-			if structDiff.NotEqual() || tlsChanged || ingressClassChanged {
+			annotationsPruned := pruneManagedAnnotations(expected.Annotations, object.Annotations)
+			annotationsChanged := !utils.MapContains(object.Annotations, expected.Annotations)
+			if structDiff.NotEqual() || rulesChanged || tlsChanged || ingressClassChanged || annotationsPruned || annotationsChanged {
 				expected.Spec.DeepCopyInto(&object.Spec)
 				utils.MergeMapsSrcDst(expected.Labels, object.Labels)
+				if object.Annotations == nil {
+					object.Annotations = make(map[string]string)
+				}
+				utils.MergeMapsSrcDst(expected.Annotations, object.Annotations)
 				return true, m.Client().Update(ctx, object)
 			}
 			return false, structDiff.Error()
@@ -54,20 +85,47 @@ func (m *manager) newIngressHandler(plant *apiv1.Plant, tlsSecretName *string) r
 
 func defineIngress(plant *apiv1.Plant, tlsSecretName *string) *networkingv1.Ingress {
 	// Defaults
-	var ingressTls []networkingv1.IngressTLS
-	if tlsSecretName != nil {
-		ingressTls = append(ingressTls, networkingv1.IngressTLS{
-			Hosts:      []string{plant.Spec.Host},
-			SecretName: *tlsSecretName,
-		})
+	hosts := plant.GetHosts()
+	if plant.Spec.RedirectToHost {
+		hosts = hosts[:1] // additional hosts are handled by redirect Ingress
 	}
 
-	containerPort := apiv1.DefaultContainerPort
-	if plant.Spec.ContainerPort != nil {
-		containerPort = *plant.Spec.ContainerPort
+	portNumbers := make(map[string]int32)
+	ports := plant.GetPorts()
+	for _, port := range ports {
+		portNumbers[port.Name] = port.ContainerPort
 	}
 
-	ingressPathType := networkingv1.PathTypePrefix
+	paths := plant.Spec.Paths
+	if len(paths) == 0 {
+		paths = []apiv1.IngressPath{{}}
+	}
+	ingressPaths := make([]networkingv1.HTTPIngressPath, 0, len(paths))
+	for _, path := range paths {
+		ingressPath := networkingv1.HTTPIngressPath{
+			Path:     path.Path,
+			PathType: path.PathType,
+			Backend: networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: plant.Name,
+					Port: networkingv1.ServiceBackendPort{
+						Number: ports[0].ContainerPort,
+					},
+				},
+			},
+		}
+		if ingressPath.Path == "" {
+			ingressPath.Path = apiv1.DefaultPath
+		}
+		if ingressPath.PathType == nil {
+			pathType := networkingv1.PathTypePrefix
+			ingressPath.PathType = &pathType
+		}
+		if number, ok := portNumbers[path.Port]; ok {
+			ingressPath.Backend.Service.Port.Number = number
+		}
+		ingressPaths = append(ingressPaths, ingressPath)
+	}
 
 	// Return Ingress
 	return &networkingv1.Ingress{
@@ -78,30 +136,78 @@ func defineIngress(plant *apiv1.Plant, tlsSecretName *string) *networkingv1.Ingr
 		},
 		Spec: networkingv1.IngressSpec{
 			IngressClassName: plant.Spec.IngressClassName,
-			TLS:              ingressTls,
-			Rules: []networkingv1.IngressRule{
-				{
-					Host: plant.Spec.Host,
-					IngressRuleValue: networkingv1.IngressRuleValue{
-						HTTP: &networkingv1.HTTPIngressRuleValue{
-							Paths: []networkingv1.HTTPIngressPath{
-								{
-									Path:     "/",
-									PathType: &ingressPathType,
-									Backend: networkingv1.IngressBackend{
-										Service: &networkingv1.IngressServiceBackend{
-											Name: plant.Name,
-											Port: networkingv1.ServiceBackendPort{
-												Number: containerPort,
-											},
-										},
-									},
-								},
-							},
-						},
+			TLS:              defineIngressTls(hosts, tlsSecretName),
+			Rules:            defineIngressRules(hosts, ingressPaths),
+		},
+	}
+}
+
+func defineOrSkipRedirectIngress(plant *apiv1.Plant, tlsSecretName *string, annotations map[string]string) *networkingv1.Ingress {
+	// Skip if not requested
+	if plant.GetRoutingMode() != apiv1.RoutingModeIngress || !plant.Spec.RedirectToHost || len(plant.Spec.AdditionalHosts) == 0 {
+		return nil
+	}
+
+	// Defaults
+	ingressPathType := networkingv1.PathTypePrefix
+	ingressPaths := []networkingv1.HTTPIngressPath{
+		{
+			Path:     apiv1.DefaultPath,
+			PathType: &ingressPathType,
+			Backend: networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: plant.Name,
+					Port: networkingv1.ServiceBackendPort{
+						Number: plant.GetPorts()[0].ContainerPort,
 					},
 				},
 			},
 		},
 	}
+
+	// Return Ingress
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        redirectIngressName(plant),
+			Namespace:   plant.Namespace,
+			Labels:      plant.OperatorLabels(),
+			Annotations: annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: plant.Spec.IngressClassName,
+			TLS:              defineIngressTls(plant.Spec.AdditionalHosts, tlsSecretName),
+			Rules:            defineIngressRules(plant.Spec.AdditionalHosts, ingressPaths),
+		},
+	}
+}
+
+func defineIngressTls(hosts []string, tlsSecretName *string) []networkingv1.IngressTLS {
+	if tlsSecretName == nil {
+		return nil
+	}
+	return []networkingv1.IngressTLS{
+		{
+			Hosts:      hosts,
+			SecretName: *tlsSecretName,
+		},
+	}
+}
+
+func defineIngressRules(hosts []string, paths []networkingv1.HTTPIngressPath) []networkingv1.IngressRule {
+	rules := make([]networkingv1.IngressRule, 0, len(hosts))
+	for _, host := range hosts {
+		rules = append(rules, networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: paths,
+				},
+			},
+		})
+	}
+	return rules
+}
+
+func redirectIngressName(plant *apiv1.Plant) string {
+	return fmt.Sprintf("%s-redirect", plant.Name)
 }
@@ -3,14 +3,21 @@ package workflow
 import (
 	"context"
 	"errors"
+	"fmt"
 	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	apiv1 "github.com/fhivemind/plant-operator/api/v1"
 	"github.com/fhivemind/plant-operator/pkg/resource"
 	"golang.org/x/sync/errgroup"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 var ClientNotConfiguredErr = errors.New("manager client not configured")
@@ -29,6 +36,11 @@ type Manager interface {
 	// If the client is not set, it returns ClientNotConfiguredErr error.
 	Execute(ctx context.Context, plant *apiv1.Plant) ([]resource.ExecuteResult, error)
 
+	// Rollback reverts the Deployment pod template to the given Deployment revision.
+	// If the revision no longer exists, it returns RevisionNotFoundErr error.
+	// If the client is not set, it returns ClientNotConfiguredErr error.
+	Rollback(ctx context.Context, plant *apiv1.Plant, revision string) error
+
 	// Client returns the current Manager Kubernetes client
 	Client() client.Client
 
@@ -36,23 +48,53 @@ type Manager interface {
 	WithClient(client client.Client) Manager
 }
 
-// NewManager creates a bare Manager.
+// Option configures optional Manager features
+type Option func(*manager)
+
+// WithGatewayAPI enables Gateway routing mode. Requires Gateway API CRDs to be installed in the cluster.
+func WithGatewayAPI() Option {
+	return func(m *manager) { m.gatewayAPI = true }
+}
+
+// WithConfigReader reads ConfigMaps and Secrets referenced by Plants with the given reader instead
+// of the Manager client, e.g. with an uncached reader so that their content is not cached.
+func WithConfigReader(reader client.Reader) Option {
+	return func(m *manager) { m.configReader = reader }
+}
+
+// NewManager creates a bare Manager configured with the given options.
 // Before executing Manager.Run, make sure to configure client via Manager.WithClient
-func NewManager() Manager {
-	return &manager{}
+func NewManager(options ...Option) Manager {
+	m := &manager{}
+	for _, option := range options {
+		option(m)
+	}
+	return m
 }
 
 type manager struct {
-	client client.Client
+	client       client.Client
+	configReader client.Reader
+	gatewayAPI   bool
 }
 
 func (m *manager) Managed() []client.Object {
-	return []client.Object{
+	managed := []client.Object{
 		&appsv1.Deployment{},
 		&corev1.Service{},
 		&networkingv1.Ingress{},
 		&certv1.Certificate{},
+		&autoscalingv2.HorizontalPodAutoscaler{},
+		&policyv1.PodDisruptionBudget{},
+		&corev1.PersistentVolumeClaim{},
+		&corev1.ServiceAccount{},
+		&rbacv1.Role{},
+		&rbacv1.RoleBinding{},
 	}
+	if m.gatewayAPI {
+		managed = append(managed, &gatewayv1beta1.HTTPRoute{})
+	}
+	return managed
 }
 
 func (m *manager) Client() client.Client { return m.client }
@@ -63,22 +105,99 @@ func (m *manager) Execute(ctx context.Context, plant *apiv1.Plant) ([]resource.E
 		return nil, ClientNotConfiguredErr
 	}
 
-	// Do processing for each handler
-	procGroup := errgroup.Group{}
-	results := make([]resource.ExecuteResult, 4)
+	// Check routing support
+	if plant.GetRoutingMode() == apiv1.RoutingModeGateway && !m.gatewayAPI {
+		return nil, GatewayAPINotEnabledErr
+	}
+	annotations, err := translateIngressAnnotations(plant)
+	if err != nil {
+		return nil, fmt.Errorf("could not translate Ingress annotations: %w", err)
+	}
 
-	// Execute deployment
-	deployment := &appsv1.Deployment{}
-	service := &corev1.Service{}
-	procGroup.Go(func() error { return runWith(ctx, deployment, m.newDeploymentHandler(plant), &results[0]) })
-	procGroup.Go(func() error { return runWith(ctx, service, m.newServiceHandler(plant), &results[1]) })
+	// Compute referenced configuration hash to trigger rollouts on changes
+	configHash, err := m.configHash(ctx, plant)
+	if err != nil {
+		return nil, fmt.Errorf("could not compute referenced configuration hash: %w", err)
+	}
 
-	// Execute networking
-	certificate := &certv1.Certificate{}
-	ingress := &networkingv1.Ingress{}
+	// Define processing for each handler, results are stored in the same order
 	tlsSecretName, tlsHandler := m.newTlsOrNopHandler(plant)
-	procGroup.Go(func() error { return runWith(ctx, certificate, tlsHandler, &results[2]) })
-	procGroup.Go(func() error { return runWith(ctx, ingress, m.newIngressHandler(plant, tlsSecretName), &results[3]) })
+	runs := []func(result *resource.ExecuteResult) error{
+		// Execute deployment
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &appsv1.Deployment{}, m.newDeploymentHandler(plant, configHash), result)
+		},
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &corev1.Service{}, m.newServiceHandler(plant, annotations.service), result)
+		},
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &autoscalingv2.HorizontalPodAutoscaler{}, m.newAutoscalerOrRemoveHandler(plant), result)
+		},
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &policyv1.PodDisruptionBudget{}, m.newDisruptionBudgetOrRemoveHandler(plant), result)
+		},
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &corev1.PersistentVolumeClaim{}, m.newClaimOrRemoveHandler(plant), result)
+		},
+
+		// Execute canary release
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &appsv1.Deployment{}, m.newCanaryDeploymentOrRemoveHandler(plant, configHash), result)
+		},
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &corev1.Service{}, m.newCanaryServiceOrRemoveHandler(plant), result)
+		},
+
+		// Execute variant routing
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &appsv1.Deployment{}, m.newVariantDeploymentOrRemoveHandler(plant, configHash), result)
+		},
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &corev1.Service{}, m.newVariantServiceOrRemoveHandler(plant), result)
+		},
+
+		// Execute identity
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &corev1.ServiceAccount{}, m.newServiceAccountOrRemoveHandler(plant), result)
+		},
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &rbacv1.Role{}, m.newRoleOrRemoveHandler(plant), result)
+		},
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &rbacv1.RoleBinding{}, m.newRoleBindingOrRemoveHandler(plant), result)
+		},
+
+		// Execute networking
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &certv1.Certificate{}, tlsHandler, result)
+		},
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &networkingv1.Ingress{}, m.newIngressOrRemoveHandler(plant, tlsSecretName, annotations.ingress), result)
+		},
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &networkingv1.Ingress{}, m.newRedirectIngressOrRemoveHandler(plant, tlsSecretName, annotations.redirect), result)
+		},
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &networkingv1.Ingress{}, m.newCanaryIngressOrRemoveHandler(plant, tlsSecretName, annotations.canary), result)
+		},
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &networkingv1.Ingress{}, m.newVariantIngressOrRemoveHandler(plant, tlsSecretName, annotations.variant), result)
+		},
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &gatewayv1beta1.HTTPRoute{}, m.newRouteOrRemoveHandler(plant), result)
+		},
+		func(result *resource.ExecuteResult) error {
+			return runWith(ctx, &gatewayv1beta1.HTTPRoute{}, m.newRedirectRouteOrRemoveHandler(plant), result)
+		},
+	}
+
+	// Do processing for each handler
+	procGroup := errgroup.Group{}
+	results := make([]resource.ExecuteResult, len(runs))
+	for i := range runs {
+		run, result := runs[i], &results[i]
+		procGroup.Go(func() error { return run(result) })
+	}
 
 	// Return
 	return results, procGroup.Wait()
@@ -89,6 +208,21 @@ func (m *manager) WithClient(client client.Client) Manager {
 	return m
 }
 
+// newRemoveHandler creates resource.RemoveExecutor which deletes the named object if it is controlled by Plant
+func newRemoveHandler[T client.Object](m *manager, name string, plant *apiv1.Plant, objectName string) resource.Executor[T] {
+	return resource.RemoveExecutor[T](name,
+		func(ctx context.Context, object T) error {
+			return m.Client().Get(ctx, types.NamespacedName{Namespace: plant.Namespace, Name: objectName}, object)
+		},
+		func(ctx context.Context, object T) error {
+			if !metav1.IsControlledBy(object, plant) {
+				return nil // not managed by this Plant, leave as is
+			}
+			return m.Client().Delete(ctx, object)
+		},
+	)
+}
+
 // runWith handles sub-resource execution using dynamic resource.Executor
 func runWith[T client.Object](ctx context.Context, obj T, handler resource.Executor[T], result *resource.ExecuteResult) error {
 	*result = handler.Execute(ctx, obj)
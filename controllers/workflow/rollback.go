@@ -0,0 +1,69 @@
+package workflow
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// deploymentRevisionAnnotation defines the annotation which Deployment controller uses to track revisions
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// RevisionNotFoundErr indicates that the requested Deployment revision no longer exists
+var RevisionNotFoundErr = errors.New("deployment revision not found")
+
+// DeploymentRevision returns the current revision of the Deployment.
+func DeploymentRevision(deployment *appsv1.Deployment) string {
+	return deployment.Annotations[deploymentRevisionAnnotation]
+}
+
+// PodTemplateHash returns a hash identifying the pod template of the Deployment.
+func PodTemplateHash(deployment *appsv1.Deployment) string {
+	data, _ := json.Marshal(deployment.Spec.Template) // pod template is always serializable
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:8])
+}
+
+func (m *manager) Rollback(ctx context.Context, plant *apiv1.Plant, revision string) error {
+	// Check client
+	if m.client == nil {
+		return ClientNotConfiguredErr
+	}
+
+	// Fetch Deployment
+	deployment := &appsv1.Deployment{}
+	if err := m.Client().Get(ctx, types.NamespacedName{Namespace: plant.Namespace, Name: plant.Name}, deployment); err != nil {
+		return err
+	}
+
+	// Find ReplicaSet of the requested revision
+	replicaSets := &appsv1.ReplicaSetList{}
+	if err := m.Client().List(ctx, replicaSets,
+		client.InNamespace(plant.Namespace), client.MatchingLabels(plant.OperatorLabels())); err != nil {
+		return err
+	}
+	var replicaSet *appsv1.ReplicaSet
+	for i := range replicaSets.Items {
+		item := &replicaSets.Items[i]
+		if metav1.IsControlledBy(item, deployment) && item.Annotations[deploymentRevisionAnnotation] == revision {
+			replicaSet = item
+			break
+		}
+	}
+	if replicaSet == nil {
+		return fmt.Errorf("%w: %s", RevisionNotFoundErr, revision)
+	}
+
+	// Restore pod template without ReplicaSet specific labels
+	replicaSet.Spec.Template.DeepCopyInto(&deployment.Spec.Template)
+	delete(deployment.Spec.Template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	return m.Client().Update(ctx, deployment)
+}
@@ -0,0 +1,298 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"github.com/fhivemind/plant-operator/pkg/resource"
+	"github.com/fhivemind/plant-operator/pkg/utils"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"regexp"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// GatewayAPINotEnabledErr indicates that Plant requests Gateway routing mode but Manager does not support it
+var GatewayAPINotEnabledErr = errors.New("gateway routing mode requested but Gateway API support is not enabled")
+
+// newRouteOrRemoveHandler creates either an HTTPRoute resource.Executor or a resource.RemoveExecutor
+// depending on the state of Plant. Returns resource.NopExecutor if Gateway API is not enabled.
+func (m *manager) newRouteOrRemoveHandler(plant *apiv1.Plant) resource.Executor[*gatewayv1beta1.HTTPRoute] {
+	switch {
+	case !m.gatewayAPI:
+		return resource.NopExecutor[*gatewayv1beta1.HTTPRoute]("HTTPRoute")
+	case plant.GetRoutingMode() != apiv1.RoutingModeGateway:
+		return newRemoveHandler[*gatewayv1beta1.HTTPRoute](m, "HTTPRoute", plant, plant.Name)
+	}
+	return m.newRouteExecutor("HTTPRoute", plant, defineRoute(plant))
+}
+
+// newRedirectRouteOrRemoveHandler creates either a redirect HTTPRoute resource.Executor or a
+// resource.RemoveExecutor depending on the state of Plant. Returns resource.NopExecutor if
+// Gateway API is not enabled.
+// Redirect HTTPRoute handles traffic for AdditionalHosts when RedirectToHost is requested.
+func (m *manager) newRedirectRouteOrRemoveHandler(plant *apiv1.Plant) resource.Executor[*gatewayv1beta1.HTTPRoute] {
+	if !m.gatewayAPI {
+		return resource.NopExecutor[*gatewayv1beta1.HTTPRoute]("RedirectHTTPRoute")
+	}
+	expected := defineOrSkipRedirectRoute(plant)
+	if expected == nil {
+		return newRemoveHandler[*gatewayv1beta1.HTTPRoute](m, "RedirectHTTPRoute", plant, redirectIngressName(plant))
+	}
+	return m.newRouteExecutor("RedirectHTTPRoute", plant, expected)
+}
+
+// newRouteExecutor creates resource.Executor for the expected HTTPRoute
+func (m *manager) newRouteExecutor(name string, plant *apiv1.Plant, expected *gatewayv1beta1.HTTPRoute) resource.Executor[*gatewayv1beta1.HTTPRoute] {
+	return resource.Executor[*gatewayv1beta1.HTTPRoute]{
+		Name: name,
+		FetchFunc: func(ctx context.Context, object *gatewayv1beta1.HTTPRoute) error {
+			return m.Client().Get(ctx, types.NamespacedName{Namespace: expected.Namespace, Name: expected.Name}, object)
+		},
+		CreateFunc: func(ctx context.Context, object *gatewayv1beta1.HTTPRoute) error {
+			expected.DeepCopyInto(object) // fill with required values
+			if err := controllerutil.SetControllerReference(plant, object, m.Client().Scheme()); err != nil {
+				return err
+			}
+			return m.Client().Create(ctx, object)
+		},
+		UpdateFunc: func(ctx context.Context, object *gatewayv1beta1.HTTPRoute) (bool, error) {
+			if !equality.Semantic.DeepEqual(expected.Spec, object.Spec) {
+				expected.Spec.DeepCopyInto(&object.Spec)
+				utils.MergeMapsSrcDst(expected.Labels, object.Labels)
+				return true, m.Client().Update(ctx, object)
+			}
+			return false, nil
+		},
+		IsReady: func(_ context.Context, object *gatewayv1beta1.HTTPRoute) bool {
+			return routeStatus(object) == ""
+		},
+		StatusFunc: func(_ context.Context, object *gatewayv1beta1.HTTPRoute) error {
+			return resource.NewNotReadyError(routeStatus(object))
+		},
+	}
+}
+
+// routeStatus returns the reason why HTTPRoute is not ready, or an empty string if the route
+// is accepted by all its parent Gateways and all its references are resolved.
+func routeStatus(route *gatewayv1beta1.HTTPRoute) string {
+	for _, parentRef := range route.Spec.ParentRefs {
+		var parentStatus *gatewayv1beta1.RouteParentStatus
+		for i := range route.Status.Parents {
+			if sameParent(route.Namespace, route.Status.Parents[i].ParentRef, parentRef) {
+				parentStatus = &route.Status.Parents[i]
+				break
+			}
+		}
+		if parentStatus == nil {
+			return fmt.Sprintf("waiting for Gateway %s to accept the route", parentRef.Name)
+		}
+		for _, conditionType := range []gatewayv1beta1.RouteConditionType{
+			gatewayv1beta1.RouteConditionAccepted, gatewayv1beta1.RouteConditionResolvedRefs,
+		} {
+			condition := meta.FindStatusCondition(parentStatus.Conditions, string(conditionType))
+			switch {
+			case condition == nil || condition.ObservedGeneration < route.Generation:
+				return fmt.Sprintf("waiting for Gateway %s to report %s condition", parentRef.Name, conditionType)
+			case condition.Status != metav1.ConditionTrue:
+				return fmt.Sprintf("Gateway %s reports %s=%s: %s", parentRef.Name, conditionType, condition.Status, condition.Message)
+			}
+		}
+	}
+	return ""
+}
+
+// sameParent returns true if both references point to the same Gateway listener. Group and kind
+// are not compared since Gateway implementations report them with defaults filled in.
+func sameParent(routeNamespace string, a, b gatewayv1beta1.ParentReference) bool {
+	namespace := func(ref gatewayv1beta1.ParentReference) string {
+		if ref.Namespace == nil || *ref.Namespace == "" {
+			return routeNamespace
+		}
+		return string(*ref.Namespace)
+	}
+	sectionName := func(ref gatewayv1beta1.ParentReference) string {
+		if ref.SectionName == nil {
+			return ""
+		}
+		return string(*ref.SectionName)
+	}
+	return a.Name == b.Name && namespace(a) == namespace(b) && sectionName(a) == sectionName(b)
+}
+
+func defineRoute(plant *apiv1.Plant) *gatewayv1beta1.HTTPRoute {
+	// Defaults
+	hosts := plant.GetHosts()
+	if plant.Spec.RedirectToHost {
+		hosts = hosts[:1] // additional hosts are handled by redirect HTTPRoute
+	}
+
+	portNumbers := make(map[string]int32)
+	ports := plant.GetPorts()
+	for _, port := range ports {
+		portNumbers[port.Name] = port.ContainerPort
+	}
+
+	paths := plant.Spec.Paths
+	if len(paths) == 0 {
+		paths = []apiv1.IngressPath{{}}
+	}
+	rules := make([]gatewayv1beta1.HTTPRouteRule, 0, 2*len(paths))
+	for _, path := range paths {
+		port := ports[0].ContainerPort
+		if number, ok := portNumbers[path.Port]; ok {
+			port = number
+		}
+		if plant.VariantActive() { // route matched requests to variant Service
+			rules = append(rules, gatewayv1beta1.HTTPRouteRule{
+				Matches:     defineRouteVariantMatches(path, plant.Spec.Routing.Matches),
+				BackendRefs: []gatewayv1beta1.HTTPBackendRef{defineRouteBackendRef(plant.VariantName(), port, 1)},
+			})
+		}
+		backendRefs := []gatewayv1beta1.HTTPBackendRef{defineRouteBackendRef(plant.Name, port, 1)}
+		if plant.CanaryActive() { // split traffic between stable and canary Service
+			weight := plant.Status.Release.Weight
+			backendRefs = []gatewayv1beta1.HTTPBackendRef{
+				defineRouteBackendRef(plant.Name, port, 100-weight),
+				defineRouteBackendRef(plant.CanaryName(), port, weight),
+			}
+		}
+		rules = append(rules, gatewayv1beta1.HTTPRouteRule{
+			Matches:     []gatewayv1beta1.HTTPRouteMatch{{Path: defineRoutePathMatch(path)}},
+			BackendRefs: backendRefs,
+		})
+	}
+
+	// Return HTTPRoute
+	return &gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      plant.Name,
+			Namespace: plant.Namespace,
+			Labels:    plant.OperatorLabels(),
+		},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: defineRouteParents(plant),
+			Hostnames:       defineRouteHostnames(hosts),
+			Rules:           rules,
+		},
+	}
+}
+
+func defineOrSkipRedirectRoute(plant *apiv1.Plant) *gatewayv1beta1.HTTPRoute {
+	// Skip if not requested
+	if plant.GetRoutingMode() != apiv1.RoutingModeGateway || !plant.Spec.RedirectToHost || len(plant.Spec.AdditionalHosts) == 0 {
+		return nil
+	}
+
+	// Return HTTPRoute
+	return &gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      redirectIngressName(plant),
+			Namespace: plant.Namespace,
+			Labels:    plant.OperatorLabels(),
+		},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: defineRouteParents(plant),
+			Hostnames:       defineRouteHostnames(plant.Spec.AdditionalHosts),
+			Rules: []gatewayv1beta1.HTTPRouteRule{{
+				Matches: []gatewayv1beta1.HTTPRouteMatch{{Path: defineRoutePathMatch(apiv1.IngressPath{})}},
+				Filters: []gatewayv1beta1.HTTPRouteFilter{{
+					Type: gatewayv1beta1.HTTPRouteFilterRequestRedirect,
+					RequestRedirect: &gatewayv1beta1.HTTPRequestRedirectFilter{
+						Hostname:   utils.Pointer(gatewayv1beta1.PreciseHostname(plant.Spec.Host)),
+						StatusCode: utils.Pointer(http.StatusMovedPermanently),
+					},
+				}},
+			}},
+		},
+	}
+}
+
+func defineRouteParents(plant *apiv1.Plant) gatewayv1beta1.CommonRouteSpec {
+	gateway := plant.Spec.Routing.Gateway
+	parentRef := gatewayv1beta1.ParentReference{
+		Group: utils.Pointer(gatewayv1beta1.Group(gatewayv1beta1.GroupName)),
+		Kind:  utils.Pointer(gatewayv1beta1.Kind("Gateway")),
+		Name:  gatewayv1beta1.ObjectName(gateway.Name),
+	}
+	if gateway.Namespace != "" {
+		parentRef.Namespace = utils.Pointer(gatewayv1beta1.Namespace(gateway.Namespace))
+	}
+	if gateway.SectionName != "" {
+		parentRef.SectionName = utils.Pointer(gatewayv1beta1.SectionName(gateway.SectionName))
+	}
+	return gatewayv1beta1.CommonRouteSpec{ParentRefs: []gatewayv1beta1.ParentReference{parentRef}}
+}
+
+func defineRouteHostnames(hosts []string) []gatewayv1beta1.Hostname {
+	hostnames := make([]gatewayv1beta1.Hostname, 0, len(hosts))
+	for _, host := range hosts {
+		hostnames = append(hostnames, gatewayv1beta1.Hostname(host))
+	}
+	return hostnames
+}
+
+// defineRouteBackendRef defines a weighted Service backend with defaults set
+func defineRouteBackendRef(serviceName string, port int32, weight int32) gatewayv1beta1.HTTPBackendRef {
+	return gatewayv1beta1.HTTPBackendRef{
+		BackendRef: gatewayv1beta1.BackendRef{
+			BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+				Group: utils.Pointer(gatewayv1beta1.Group("")),
+				Kind:  utils.Pointer(gatewayv1beta1.Kind("Service")),
+				Name:  gatewayv1beta1.ObjectName(serviceName),
+				Port:  utils.Pointer(gatewayv1beta1.PortNumber(port)),
+			},
+			Weight: &weight,
+		},
+	}
+}
+
+// defineRouteVariantMatches translates routing matches for the path into HTTPRoute matches with
+// defaults set. Cookies are matched with a regular expression on the Cookie header.
+func defineRouteVariantMatches(path apiv1.IngressPath, matches []apiv1.RouteMatch) []gatewayv1beta1.HTTPRouteMatch {
+	routeMatches := make([]gatewayv1beta1.HTTPRouteMatch, 0, len(matches))
+	for _, match := range matches {
+		routeMatch := gatewayv1beta1.HTTPRouteMatch{Path: defineRoutePathMatch(path)}
+		switch match.Type {
+		case apiv1.RouteMatchHeader:
+			routeMatch.Headers = []gatewayv1beta1.HTTPHeaderMatch{{
+				Type:  utils.Pointer(gatewayv1beta1.HeaderMatchExact),
+				Name:  gatewayv1beta1.HTTPHeaderName(match.Name),
+				Value: match.Value,
+			}}
+		case apiv1.RouteMatchCookie:
+			routeMatch.Headers = []gatewayv1beta1.HTTPHeaderMatch{{
+				Type:  utils.Pointer(gatewayv1beta1.HeaderMatchRegularExpression),
+				Name:  "Cookie",
+				Value: fmt.Sprintf(`(^|;\s*)%s=%s(;|$)`, regexp.QuoteMeta(match.Name), regexp.QuoteMeta(match.Value)),
+			}}
+		case apiv1.RouteMatchQueryParam:
+			routeMatch.QueryParams = []gatewayv1beta1.HTTPQueryParamMatch{{
+				Type:  utils.Pointer(gatewayv1beta1.QueryParamMatchExact),
+				Name:  match.Name,
+				Value: match.Value,
+			}}
+		}
+		routeMatches = append(routeMatches, routeMatch)
+	}
+	return routeMatches
+}
+
+// defineRoutePathMatch translates IngressPath into HTTPRoute path match with defaults set
+func defineRoutePathMatch(path apiv1.IngressPath) *gatewayv1beta1.HTTPPathMatch {
+	matchType := gatewayv1beta1.PathMatchPathPrefix
+	if path.PathType != nil && *path.PathType == networkingv1.PathTypeExact {
+		matchType = gatewayv1beta1.PathMatchExact
+	}
+	value := path.Path
+	if value == "" {
+		value = apiv1.DefaultPath
+	}
+	return &gatewayv1beta1.HTTPPathMatch{Type: &matchType, Value: &value}
+}
@@ -0,0 +1,62 @@
+package workflow
+
+import (
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	hostAntiAffinityWeight int32 = 100 // hostAntiAffinityWeight defines the preference to spread pods across nodes
+	zoneAntiAffinityWeight int32 = 50  // zoneAntiAffinityWeight defines the preference to spread pods across zones
+)
+
+// defineAffinity returns Plant affinity. If HighAvailability is requested, it adds preferred
+// pod anti-affinity across nodes and zones unless PodAntiAffinity is specified.
+func defineAffinity(plant *apiv1.Plant) *corev1.Affinity {
+	affinity := plant.Spec.Affinity.DeepCopy()
+	if !plant.Spec.HighAvailability || (affinity != nil && affinity.PodAntiAffinity != nil) {
+		return affinity
+	}
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+	}
+
+	selector := &metav1.LabelSelector{MatchLabels: plant.OperatorLabels()}
+	affinity.PodAntiAffinity = &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+			{
+				Weight: hostAntiAffinityWeight,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					LabelSelector: selector,
+					TopologyKey:   corev1.LabelHostname,
+				},
+			},
+			{
+				Weight: zoneAntiAffinityWeight,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					LabelSelector: selector,
+					TopologyKey:   corev1.LabelTopologyZone,
+				},
+			},
+		},
+	}
+	return affinity
+}
+
+// defineTopologySpreadConstraints returns Plant topology spread constraints. Constraints without
+// label selector select Plant pods.
+func defineTopologySpreadConstraints(plant *apiv1.Plant) []corev1.TopologySpreadConstraint {
+	if len(plant.Spec.TopologySpreadConstraints) == 0 {
+		return nil
+	}
+	constraints := make([]corev1.TopologySpreadConstraint, 0, len(plant.Spec.TopologySpreadConstraints))
+	for _, constraint := range plant.Spec.TopologySpreadConstraints {
+		constraint := *constraint.DeepCopy()
+		if constraint.LabelSelector == nil {
+			constraint.LabelSelector = &metav1.LabelSelector{MatchLabels: plant.OperatorLabels()}
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints
+}
@@ -12,15 +12,22 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-// newServiceHandler creates service resource.Executor for the given Plant
-func (m *manager) newServiceHandler(plant *apiv1.Plant) resource.Executor[*corev1.Service] {
-	// Create expected object
-	expected := defineService(plant)
+// newServiceHandler creates service resource.Executor for the given Plant. Annotations configure
+// ingress options for the Ingress class. Requested and ingress option annotations are removed
+// from the Service once no longer expected.
+func (m *manager) newServiceHandler(plant *apiv1.Plant, annotations map[string]string) resource.Executor[*corev1.Service] {
+	service := defineService(plant)
+	service.Annotations = withManagedAnnotations(service.Annotations, annotations)
+	return m.newServiceExecutor("Service", plant, service)
+}
+
+// newServiceExecutor creates resource.Executor for the expected Service
+func (m *manager) newServiceExecutor(name string, plant *apiv1.Plant, expected *corev1.Service) resource.Executor[*corev1.Service] {
 	m.Client().Scheme().Default(expected)
 
 	// Return handler
 	return resource.Executor[*corev1.Service]{
-		Name: "Service",
+		Name: name,
 		FetchFunc: func(ctx context.Context, object *corev1.Service) error {
 			return m.Client().Get(ctx, types.NamespacedName{Namespace: expected.Namespace, Name: expected.Name}, object)
 		},
@@ -33,9 +40,16 @@ func (m *manager) newServiceHandler(plant *apiv1.Plant) resource.Executor[*corev
 		},
 		UpdateFunc: func(ctx context.Context, object *corev1.Service) (bool, error) {
 			diff := utils.Diff(&expected.Spec, &object.Spec)
-			if diff.NotEqual() {
-				expected.Spec.DeepCopyInto(&object.Spec)
+			annotationsPruned := pruneManagedAnnotations(expected.Annotations, object.Annotations)
+			annotationsChanged := !utils.MapContains(object.Annotations, expected.Annotations)
+			portsChanged := len(expected.Spec.Ports) != len(object.Spec.Ports)
+			if diff.NotEqual() || annotationsPruned || annotationsChanged || portsChanged {
+				mergeServiceSpec(&expected.Spec, &object.Spec)
 				utils.MergeMapsSrcDst(expected.Labels, object.Labels)
+				if object.Annotations == nil {
+					object.Annotations = make(map[string]string)
+				}
+				utils.MergeMapsSrcDst(expected.Annotations, object.Annotations)
 				return true, m.Client().Update(ctx, object)
 			}
 			return false, diff.Error()
@@ -48,28 +62,76 @@ func (m *manager) newServiceHandler(plant *apiv1.Plant) resource.Executor[*corev
 
 func defineService(plant *apiv1.Plant) *corev1.Service {
 	// Defaults
-	containerPort := apiv1.DefaultContainerPort
-	if plant.Spec.ContainerPort != nil {
-		containerPort = *plant.Spec.ContainerPort
+	var servicePorts []corev1.ServicePort
+	for _, port := range plant.GetPorts() {
+		servicePorts = append(servicePorts, corev1.ServicePort{
+			Name:       port.Name,
+			Protocol:   port.Protocol,
+			Port:       port.ContainerPort,
+			TargetPort: intstr.FromInt(int(port.ContainerPort)),
+		})
+	}
+
+	serviceType := apiv1.DefaultServiceType
+	sessionAffinity := corev1.ServiceAffinityNone
+	var externalTrafficPolicy corev1.ServiceExternalTrafficPolicyType
+	var annotations map[string]string
+	if config := plant.Spec.Service; config != nil {
+		if config.Type != "" {
+			serviceType = config.Type
+		}
+		if config.SessionAffinity != "" {
+			sessionAffinity = config.SessionAffinity
+		}
+		externalTrafficPolicy = config.ExternalTrafficPolicy
+		annotations = config.Annotations
+	}
+	if serviceType != corev1.ServiceTypeClusterIP && externalTrafficPolicy == "" {
+		externalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
 	}
 
 	// Return Service
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      plant.Name,
-			Namespace: plant.Namespace,
-			Labels:    plant.OperatorLabels(),
+			Name:        plant.Name,
+			Namespace:   plant.Namespace,
+			Labels:      plant.OperatorLabels(),
+			Annotations: annotations,
 		},
 		Spec: corev1.ServiceSpec{
-			Ports: []corev1.ServicePort{
-				{
-					Protocol:   corev1.ProtocolTCP,
-					Port:       containerPort,
-					TargetPort: intstr.FromInt(int(containerPort)),
-				},
-			},
-			Selector: plant.OperatorLabels(),
-			Type:     corev1.ServiceTypeNodePort,
+			Ports:                 servicePorts,
+			Selector:              plant.OperatorLabels(),
+			Type:                  serviceType,
+			SessionAffinity:       sessionAffinity,
+			ExternalTrafficPolicy: externalTrafficPolicy,
 		},
 	}
 }
+
+// mergeServiceSpec overwrites received with expected spec while preserving values allocated
+// by the API server. Node ports are cleared when switching to ClusterIP type, and
+// kept for matching ports otherwise.
+func mergeServiceSpec(expected, received *corev1.ServiceSpec) {
+	// Store allocated values
+	clusterIP, clusterIPs := received.ClusterIP, received.ClusterIPs
+	ipFamilies, ipFamilyPolicy := received.IPFamilies, received.IPFamilyPolicy
+	healthCheckNodePort := received.HealthCheckNodePort
+	nodePorts := make(map[int32]int32)
+	for _, port := range received.Ports {
+		nodePorts[port.Port] = port.NodePort
+	}
+
+	// Overwrite and restore
+	expected.DeepCopyInto(received)
+	received.ClusterIP, received.ClusterIPs = clusterIP, clusterIPs
+	received.IPFamilies, received.IPFamilyPolicy = ipFamilies, ipFamilyPolicy
+	if received.Type != corev1.ServiceTypeClusterIP {
+		for i := range received.Ports {
+			received.Ports[i].NodePort = nodePorts[received.Ports[i].Port]
+		}
+	}
+	if received.Type == corev1.ServiceTypeLoadBalancer &&
+		received.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyTypeLocal {
+		received.HealthCheckNodePort = healthCheckNodePort
+	}
+}
@@ -0,0 +1,76 @@
+package workflow
+
+import (
+	apiv1 "github.com/fhivemind/plant-operator/api/v1"
+	"github.com/fhivemind/plant-operator/pkg/resource"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newVariantDeploymentOrRemoveHandler creates either a variant Deployment resource.Executor or a
+// resource.RemoveExecutor depending on the routing matches of Plant.
+func (m *manager) newVariantDeploymentOrRemoveHandler(plant *apiv1.Plant, configHash string) resource.Executor[*appsv1.Deployment] {
+	if !plant.VariantActive() {
+		return newRemoveHandler[*appsv1.Deployment](m, "VariantDeployment", plant, plant.VariantName())
+	}
+	return m.newDeploymentExecutor("VariantDeployment", plant, defineVariantDeployment(plant, configHash), false)
+}
+
+// newVariantServiceOrRemoveHandler creates either a variant Service resource.Executor or a
+// resource.RemoveExecutor depending on the routing matches of Plant.
+func (m *manager) newVariantServiceOrRemoveHandler(plant *apiv1.Plant) resource.Executor[*corev1.Service] {
+	if !plant.VariantActive() {
+		return newRemoveHandler[*corev1.Service](m, "VariantService", plant, plant.VariantName())
+	}
+	return m.newServiceExecutor("VariantService", plant, defineVariantService(plant))
+}
+
+// newVariantIngressOrRemoveHandler creates either a variant Ingress resource.Executor or a
+// resource.RemoveExecutor depending on the routing matches and routing mode of Plant.
+// Variant Ingress routes matched requests using annotations translated for the Ingress class.
+func (m *manager) newVariantIngressOrRemoveHandler(plant *apiv1.Plant, tlsSecretName *string, annotations map[string]string) resource.Executor[*networkingv1.Ingress] {
+	if !plant.VariantActive() || plant.GetRoutingMode() != apiv1.RoutingModeIngress {
+		return newRemoveHandler[*networkingv1.Ingress](m, "VariantIngress", plant, plant.VariantName())
+	}
+	return m.newIngressExecutor("VariantIngress", plant, defineVariantIngress(plant, tlsSecretName, annotations))
+}
+
+// defineVariantDeployment defines a Deployment of the variant image with pods selected by
+// variant labels. Replicas are not autoscaled.
+func defineVariantDeployment(plant *apiv1.Plant, configHash string) *appsv1.Deployment {
+	replicas := plant.GetMinReplicas()
+	deployment := defineDeployment(plant, configHash)
+	deployment.Name = plant.VariantName()
+	deployment.Spec.Replicas = &replicas
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: plant.VariantLabels()}
+	deployment.Spec.Template.Labels = plant.VariantLabels()
+	deployment.Spec.Template.Spec.Containers[0].Image = plant.Spec.Routing.VariantImage
+	return deployment
+}
+
+// defineVariantService defines an internal Service exposing variant pods
+func defineVariantService(plant *apiv1.Plant) *corev1.Service {
+	service := defineService(plant)
+	service.Name = plant.VariantName()
+	service.Annotations = nil
+	service.Spec.Selector = plant.VariantLabels()
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	service.Spec.ExternalTrafficPolicy = ""
+	return service
+}
+
+// defineVariantIngress defines an Ingress for the Plant host which sends requests selected
+// by annotations to the variant Service
+func defineVariantIngress(plant *apiv1.Plant, tlsSecretName *string, annotations map[string]string) *networkingv1.Ingress {
+	variantIngress := defineIngress(plant, tlsSecretName)
+	variantIngress.Name = plant.VariantName()
+	variantIngress.Annotations = annotations
+	for _, rule := range variantIngress.Spec.Rules {
+		for i := range rule.HTTP.Paths {
+			rule.HTTP.Paths[i].Backend.Service.Name = plant.VariantName()
+		}
+	}
+	return variantIngress
+}
@@ -20,7 +20,9 @@ import (
 	"flag"
 	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/fhivemind/plant-operator/controllers/workflow"
+	"github.com/fhivemind/plant-operator/pkg/ingress"
 	"os"
+	"strings"
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -31,6 +33,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	operatorv1 "github.com/fhivemind/plant-operator/api/v1"
 	"github.com/fhivemind/plant-operator/controllers"
@@ -48,15 +51,28 @@ func init() {
 	utilruntime.Must(operatorv1.AddToScheme(scheme))
 
 	utilruntime.Must(certv1.AddToScheme(scheme))
+
+	utilruntime.Must(gatewayv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
 func main() {
 	var configFile string
+	var enableGatewayAPI bool
+	var ingressClassTranslators string
+	var releaseAnalysisAddresses string
 	flag.StringVar(&configFile, "config", "",
 		"The controller will load its initial configuration from this file. "+
 			"Omit this flag to use the default configuration values. "+
 			"Command-line flags override configuration from this file.")
+	flag.BoolVar(&enableGatewayAPI, "enable-gateway-api", false,
+		"Enable Gateway routing mode for Plants. Requires Gateway API CRDs to be installed in the cluster.")
+	flag.StringVar(&ingressClassTranslators, "ingress-class-translators", "",
+		"Comma-separated list of custom Ingress class names mapped to the annotation translators of "+
+			"built-in nginx or traefik classes, e.g. internal-nginx=nginx.")
+	flag.StringVar(&releaseAnalysisAddresses, "release-analysis-addresses", "",
+		"Comma-separated list of Prometheus-compatible API addresses which canary release analysis may query, "+
+			"e.g. http://prometheus.monitoring:9090. Addresses without port allow any port of the host.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -81,15 +97,40 @@ func main() {
 		os.Exit(1)
 	}
 
+	for _, mapping := range strings.Split(ingressClassTranslators, ",") {
+		if mapping == "" {
+			continue
+		}
+		className, builtinClassName, _ := strings.Cut(mapping, "=")
+		translator, err := ingress.ForClass(&builtinClassName)
+		if err != nil {
+			setupLog.Error(err, "unable to register Ingress class translator", "ingressClassName", className)
+			os.Exit(1)
+		}
+		ingress.Register(className, translator)
+	}
+
+	workflowOptions := []workflow.Option{workflow.WithConfigReader(mgr.GetAPIReader())}
+	if enableGatewayAPI {
+		workflowOptions = append(workflowOptions, workflow.WithGatewayAPI())
+	}
+	var analysisAddresses []string
+	for _, address := range strings.Split(releaseAnalysisAddresses, ",") {
+		if address != "" {
+			analysisAddresses = append(analysisAddresses, address)
+		}
+	}
 	if err = (&controllers.PlantReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Workflow: workflow.NewManager(),
-		Recorder: mgr.GetEventRecorderFor("plant-controller"),
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		Workflow:          workflow.NewManager(workflowOptions...),
+		Recorder:          mgr.GetEventRecorderFor("plant-controller"),
+		AnalysisAddresses: analysisAddresses,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Plant")
 		os.Exit(1)
 	}
+	operatorv1.IngressClassValidator = workflow.ValidateIngressClass
 	if err = (&operatorv1.Plant{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Plant")
 		os.Exit(1)
@@ -0,0 +1,220 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verdict defines the outcome of a metric analysis
+type Verdict int
+
+const (
+	Passed       Verdict = iota // Passed indicates that all values are within thresholds
+	Failed                      // Failed indicates that a value is outside of thresholds
+	Inconclusive                // Inconclusive indicates that a value could not be obtained
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case Passed:
+		return "Passed"
+	case Failed:
+		return "Failed"
+	default:
+		return "Inconclusive"
+	}
+}
+
+var (
+	QueryFailedErr    = errors.New("metric query failed")
+	EmptyResultErr    = errors.New("metric query returned no data")
+	AmbiguousValueErr = errors.New("metric query returned more than one value")
+	NaNValueErr       = errors.New("metric query returned NaN")
+)
+
+// Metric defines a query with accepted value range. Unset bounds are not checked.
+type Metric struct {
+	Name  string
+	Query string
+	Min   *float64
+	Max   *float64
+}
+
+// Result defines the analysis outcome of a single Metric
+type Result struct {
+	Metric  string
+	Value   float64
+	Verdict Verdict
+	Err     error
+}
+
+func (r Result) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("%s: %v", r.Metric, r.Err)
+	}
+	return fmt.Sprintf("%s=%s", r.Metric, strconv.FormatFloat(r.Value, 'g', -1, 64))
+}
+
+// Client evaluates metrics against a Prometheus-compatible HTTP API.
+type Client struct {
+	address    string
+	httpClient *http.Client
+}
+
+// NewClient creates a Client for the API at the given address, e.g. http://prometheus:9090.
+// Redirects are not followed to keep requests on the given address.
+func NewClient(address string) *Client {
+	return &Client{
+		address: strings.TrimSuffix(address, "/"),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Analyze evaluates all metrics and returns their results in order.
+func (c *Client) Analyze(ctx context.Context, metrics []Metric) []Result {
+	results := make([]Result, 0, len(metrics))
+	for _, metric := range metrics {
+		result := Result{Metric: metric.Name, Verdict: Passed}
+		value, err := c.Query(ctx, metric.Query)
+		switch {
+		case err != nil:
+			result.Verdict, result.Err = Inconclusive, err
+		case metric.Min != nil && value < *metric.Min:
+			result.Value, result.Verdict = value, Failed
+		case metric.Max != nil && value > *metric.Max:
+			result.Value, result.Verdict = value, Failed
+		default:
+			result.Value = value
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// Query evaluates an instant query and returns its single value. The query must
+// return a scalar or a vector with exactly one sample.
+func (c *Client) Query(ctx context.Context, query string) (float64, error) {
+	// Send request
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		c.address+"/api/v1/query?"+url.Values{"query": {query}}.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", QueryFailedErr, err)
+	}
+	defer response.Body.Close()
+
+	// Decode response
+	var body queryResponse
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return 0, fmt.Errorf("%w: invalid response with status %d: %v", QueryFailedErr, response.StatusCode, err)
+	}
+	if body.Status != "success" {
+		return 0, fmt.Errorf("%w: %s: %s", QueryFailedErr, body.ErrorType, body.Error)
+	}
+
+	// Extract value
+	var sample []interface{}
+	switch body.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(body.Data.Result, &sample); err != nil {
+			return 0, fmt.Errorf("%w: invalid scalar: %v", QueryFailedErr, err)
+		}
+	case "vector":
+		var vector []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(body.Data.Result, &vector); err != nil {
+			return 0, fmt.Errorf("%w: invalid vector: %v", QueryFailedErr, err)
+		}
+		switch len(vector) {
+		case 0:
+			return 0, EmptyResultErr
+		case 1:
+			sample = vector[0].Value
+		default:
+			return 0, AmbiguousValueErr
+		}
+	default:
+		return 0, fmt.Errorf("%w: unsupported result type %q", QueryFailedErr, body.Data.ResultType)
+	}
+	return parseSample(sample)
+}
+
+// Summarize returns the overall verdict of results and a message listing the results.
+// Failed results take precedence over inconclusive ones.
+func Summarize(results []Result) (Verdict, string) {
+	verdict := Passed
+	messages := make([]string, 0, len(results))
+	for _, result := range results {
+		if result.Verdict == Failed || (result.Verdict == Inconclusive && verdict == Passed) {
+			verdict = result.Verdict
+		}
+		messages = append(messages, result.String())
+	}
+	return verdict, strings.Join(messages, ", ")
+}
+
+// AddressAllowed returns true if the address has the scheme and host of one of the allowed
+// addresses. Allowed addresses without port match any port of the host.
+func AddressAllowed(address string, allowed []string) bool {
+	target, err := url.Parse(address)
+	if err != nil || target.Host == "" {
+		return false
+	}
+	for _, entry := range allowed {
+		allowedURL, err := url.Parse(strings.TrimSpace(entry))
+		if err != nil || allowedURL.Host == "" || !strings.EqualFold(allowedURL.Scheme, target.Scheme) ||
+			!strings.EqualFold(allowedURL.Hostname(), target.Hostname()) {
+			continue
+		}
+		if allowedURL.Port() == "" || allowedURL.Port() == target.Port() {
+			return true
+		}
+	}
+	return false
+}
+
+type queryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// parseSample parses [<timestamp>, "<value>"] sample value
+func parseSample(sample []interface{}) (float64, error) {
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("%w: invalid sample", QueryFailedErr)
+	}
+	raw, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("%w: invalid sample value", QueryFailedErr)
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid sample value %q", QueryFailedErr, raw)
+	}
+	if math.IsNaN(value) {
+		return 0, NaNValueErr
+	}
+	return value, nil
+}
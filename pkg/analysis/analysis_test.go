@@ -0,0 +1,127 @@
+package analysis_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/fhivemind/plant-operator/pkg/analysis"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+// newPrometheus starts a fake Prometheus HTTP API which responds to known queries
+func newPrometheus(responses map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		response, ok := responses[r.URL.Query().Get("query")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"status":"error","errorType":"bad_data","error":"parse error"}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, response)
+	}))
+}
+
+func vector(values ...string) string {
+	result := ""
+	for i, value := range values {
+		if i > 0 {
+			result += ","
+		}
+		result += fmt.Sprintf(`{"metric":{},"value":[1700000000.123,%q]}`, value)
+	}
+	return fmt.Sprintf(`{"status":"success","data":{"resultType":"vector","result":[%s]}}`, result)
+}
+
+func threshold(value float64) *float64 {
+	return &value
+}
+
+var _ = Describe("Prometheus client", Ordered, func() {
+	var server *httptest.Server
+	var client *analysis.Client
+
+	BeforeAll(func() {
+		server = newPrometheus(map[string]string{
+			"error_rate":     vector("0.02"),
+			"latency":        vector("0.25"),
+			"success":        `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"0.999"]}}`,
+			"no_traffic":     vector(),
+			"per_pod":        vector("1", "2"),
+			"not_a_number":   vector("NaN"),
+			"matrix":         `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			"malformed_json": `{"status":`,
+		})
+		client = analysis.NewClient(server.URL + "/")
+	})
+
+	AfterAll(func() {
+		server.Close()
+	})
+
+	It("Should query vector and scalar values", func() {
+		Expect(client.Query(context.Background(), "error_rate")).To(Equal(0.02))
+		Expect(client.Query(context.Background(), "success")).To(Equal(0.999))
+	})
+
+	It("Should reject queries without a single value", func() {
+		_, err := client.Query(context.Background(), "no_traffic")
+		Expect(err).To(MatchError(analysis.EmptyResultErr))
+		_, err = client.Query(context.Background(), "per_pod")
+		Expect(err).To(MatchError(analysis.AmbiguousValueErr))
+		_, err = client.Query(context.Background(), "not_a_number")
+		Expect(err).To(MatchError(analysis.NaNValueErr))
+	})
+
+	It("Should report failed queries", func() {
+		for _, query := range []string{"unknown", "matrix", "malformed_json"} {
+			_, err := client.Query(context.Background(), query)
+			Expect(err).To(MatchError(analysis.QueryFailedErr), query)
+		}
+		_, err := analysis.NewClient("http://127.0.0.1:1").Query(context.Background(), "error_rate")
+		Expect(err).To(MatchError(analysis.QueryFailedErr))
+	})
+
+	It("Should evaluate metrics against thresholds", func() {
+		results := client.Analyze(context.Background(), []analysis.Metric{
+			{Name: "error-rate", Query: "error_rate", Max: threshold(0.05)},
+			{Name: "success-rate", Query: "success", Min: threshold(0.99)},
+		})
+		Expect(results).To(HaveLen(2))
+		verdict, message := analysis.Summarize(results)
+		Expect(verdict).To(Equal(analysis.Passed))
+		Expect(message).To(Equal("error-rate=0.02, success-rate=0.999"))
+
+		results = client.Analyze(context.Background(), []analysis.Metric{
+			{Name: "error-rate", Query: "error_rate", Max: threshold(0.05)},
+			{Name: "traffic", Query: "no_traffic", Min: threshold(1)},
+		})
+		verdict, message = analysis.Summarize(results)
+		Expect(verdict).To(Equal(analysis.Inconclusive))
+		Expect(message).To(ContainSubstring("traffic: metric query returned no data"))
+
+		results = client.Analyze(context.Background(), []analysis.Metric{
+			{Name: "traffic", Query: "no_traffic", Min: threshold(1)},
+			{Name: "latency", Query: "latency", Max: threshold(0.2)},
+		})
+		verdict, message = analysis.Summarize(results)
+		Expect(verdict).To(Equal(analysis.Failed))
+		Expect(message).To(ContainSubstring("latency=0.25"))
+	})
+
+	It("Should allow only listed addresses", func() {
+		allowed := []string{"http://prometheus.monitoring:9090", "https://thanos.monitoring"}
+		Expect(analysis.AddressAllowed("http://prometheus.monitoring:9090", allowed)).To(BeTrue())
+		Expect(analysis.AddressAllowed("http://prometheus.monitoring:9090/prometheus", allowed)).To(BeTrue())
+		Expect(analysis.AddressAllowed("https://thanos.monitoring:10902", allowed)).To(BeTrue())
+		Expect(analysis.AddressAllowed("http://prometheus.monitoring:8080", allowed)).To(BeFalse())
+		Expect(analysis.AddressAllowed("http://thanos.monitoring", allowed)).To(BeFalse())
+		Expect(analysis.AddressAllowed("http://169.254.169.254", allowed)).To(BeFalse())
+		Expect(analysis.AddressAllowed("http://prometheus.monitoring:9090", nil)).To(BeFalse())
+	})
+})
@@ -0,0 +1,127 @@
+package ingress
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	UnsupportedErr      = errors.New("not supported by ingress controller")
+	UnknownClassNameErr = errors.New("no translator registered for ingress class")
+)
+
+// MatchType defines the request attribute checked by Match
+type MatchType string
+
+const (
+	MatchHeader     MatchType = "Header"     // MatchHeader matches requests by header value
+	MatchCookie     MatchType = "Cookie"     // MatchCookie matches requests by cookie value
+	MatchQueryParam MatchType = "QueryParam" // MatchQueryParam matches requests by query parameter value
+)
+
+// Match selects requests with an exact attribute value
+type Match struct {
+	Type  MatchType
+	Name  string
+	Value string
+}
+
+// Protocol defines the protocol used by ingress controller to connect to pods
+type Protocol string
+
+const (
+	ProtocolHTTP  Protocol = "HTTP"  // ProtocolHTTP connects with HTTP/1.1
+	ProtocolHTTPS Protocol = "HTTPS" // ProtocolHTTPS connects with HTTP/1.1 over TLS
+	ProtocolGRPC  Protocol = "GRPC"  // ProtocolGRPC connects with gRPC over cleartext HTTP/2
+	ProtocolGRPCS Protocol = "GRPCS" // ProtocolGRPCS connects with gRPC over TLS
+)
+
+// Options defines common ingress controller features. Unset options keep controller defaults.
+type Options struct {
+	MaxBodySize     *int64 // MaxBodySize defines the maximal request body size in bytes, zero disables the limit
+	ConnectTimeout  *time.Duration
+	ReadTimeout     *time.Duration
+	SendTimeout     *time.Duration
+	HTTPSRedirect   *bool // HTTPSRedirect enables or disables redirects of HTTP requests to HTTPS
+	CORS            *CORS
+	RateLimit       *RateLimit
+	BackendProtocol Protocol
+}
+
+// CORS defines cross-origin resource sharing responses
+type CORS struct {
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	AllowCredentials bool
+	MaxAge           *time.Duration
+}
+
+// RateLimit defines limits per client IP
+type RateLimit struct {
+	RequestsPerSecond *int32
+	Connections       *int32
+}
+
+// Annotations defines annotations of objects which configure the ingress controller
+type Annotations struct {
+	Ingress map[string]string
+	Service map[string]string
+}
+
+// Translator translates routing features to Ingress annotations of a specific ingress controller.
+// Translators return UnsupportedErr for features which the controller cannot express.
+type Translator interface {
+	// Controller returns the name of the ingress controller
+	Controller() string
+
+	// OptionAnnotations returns annotations of the Ingress and its backend Service which configure options
+	OptionAnnotations(options Options) (Annotations, error)
+
+	// RedirectAnnotations returns annotations of an Ingress which permanently redirects requests
+	// to host with scheme, keeping the request path and query.
+	RedirectAnnotations(scheme, host string) (map[string]string, error)
+
+	// WeightAnnotations returns annotations of an Ingress which serves weight percent of requests,
+	// and which shares hosts and paths with the Ingress serving other requests.
+	WeightAnnotations(weight int32) (map[string]string, error)
+
+	// MatchAnnotations returns annotations of an Ingress which serves requests satisfying any
+	// of matches, and which shares hosts and paths with the Ingress serving other requests.
+	MatchAnnotations(matches []Match) (map[string]string, error)
+}
+
+var (
+	translatorsLock sync.RWMutex
+	translators     = map[string]Translator{
+		"nginx":   Nginx{},
+		"traefik": Traefik{},
+	}
+)
+
+// Register sets the Translator of Ingress class, replacing any existing one.
+// Use it to support custom Ingress class names of known ingress controllers.
+func Register(className string, translator Translator) {
+	translatorsLock.Lock()
+	defer translatorsLock.Unlock()
+	translators[className] = translator
+}
+
+// ForClass returns the Translator of Ingress class. Returns UnknownClassNameErr if class is not set,
+// since the controller of the cluster default Ingress class is not known.
+func ForClass(className *string) (Translator, error) {
+	if className == nil || *className == "" {
+		return nil, fmt.Errorf("%w: ingress class is not set", UnknownClassNameErr)
+	}
+	name := *className
+
+	translatorsLock.RLock()
+	defer translatorsLock.RUnlock()
+	translator, ok := translators[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", UnknownClassNameErr, name)
+	}
+	return translator, nil
+}
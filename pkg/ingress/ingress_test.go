@@ -0,0 +1,122 @@
+package ingress_test
+
+import (
+	"github.com/fhivemind/plant-operator/pkg/ingress"
+	"github.com/fhivemind/plant-operator/pkg/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"time"
+)
+
+var _ = Describe("Translator registry", func() {
+	It("Should reject unset class", func() {
+		_, err := ingress.ForClass(nil)
+		Expect(err).To(MatchError(ingress.UnknownClassNameErr))
+	})
+
+	It("Should reject unknown classes", func() {
+		className := "unknown"
+		_, err := ingress.ForClass(&className)
+		Expect(err).To(MatchError(ingress.UnknownClassNameErr))
+	})
+
+	It("Should register custom class names", func() {
+		className := "internal-nginx"
+		ingress.Register(className, ingress.Nginx{})
+		translator, err := ingress.ForClass(&className)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(translator.Controller()).To(Equal("ingress-nginx"))
+	})
+})
+
+var _ = Describe("Nginx translator", func() {
+	It("Should translate options", func() {
+		annotations, err := ingress.Nginx{}.OptionAnnotations(ingress.Options{
+			MaxBodySize:    utils.Pointer(int64(10 << 20)),
+			ConnectTimeout: utils.Pointer(1500 * time.Millisecond),
+			ReadTimeout:    utils.Pointer(time.Minute),
+			HTTPSRedirect:  utils.Pointer(true),
+			CORS: &ingress.CORS{
+				AllowOrigins: []string{"https://example.com", "https://example.org"},
+				AllowMethods: []string{"GET", "POST"},
+				MaxAge:       utils.Pointer(time.Hour),
+			},
+			RateLimit:       &ingress.RateLimit{RequestsPerSecond: utils.Pointer(int32(10))},
+			BackendProtocol: ingress.ProtocolGRPC,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotations.Service).To(BeEmpty())
+		Expect(annotations.Ingress).To(Equal(map[string]string{
+			"nginx.ingress.kubernetes.io/proxy-body-size":        "10485760",
+			"nginx.ingress.kubernetes.io/proxy-connect-timeout":  "2",
+			"nginx.ingress.kubernetes.io/proxy-read-timeout":     "60",
+			"nginx.ingress.kubernetes.io/ssl-redirect":           "true",
+			"nginx.ingress.kubernetes.io/force-ssl-redirect":     "true",
+			"nginx.ingress.kubernetes.io/enable-cors":            "true",
+			"nginx.ingress.kubernetes.io/cors-allow-origin":      "https://example.com, https://example.org",
+			"nginx.ingress.kubernetes.io/cors-allow-methods":     "GET, POST",
+			"nginx.ingress.kubernetes.io/cors-allow-credentials": "false",
+			"nginx.ingress.kubernetes.io/cors-max-age":           "3600",
+			"nginx.ingress.kubernetes.io/limit-rps":              "10",
+			"nginx.ingress.kubernetes.io/backend-protocol":       "GRPC",
+		}))
+	})
+
+	It("Should translate redirects and weights", func() {
+		Expect(ingress.Nginx{}.RedirectAnnotations("https", "example.host")).To(Equal(map[string]string{
+			"nginx.ingress.kubernetes.io/permanent-redirect": "https://example.host$request_uri",
+		}))
+		Expect(ingress.Nginx{}.WeightAnnotations(20)).To(Equal(map[string]string{
+			"nginx.ingress.kubernetes.io/canary":        "true",
+			"nginx.ingress.kubernetes.io/canary-weight": "20",
+		}))
+	})
+
+	It("Should translate header and cookie matches", func() {
+		annotations, err := ingress.Nginx{}.MatchAnnotations([]ingress.Match{
+			{Type: ingress.MatchHeader, Name: "X-Variant", Value: "b"},
+			{Type: ingress.MatchCookie, Name: "variant-b", Value: "always"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotations).To(Equal(map[string]string{
+			"nginx.ingress.kubernetes.io/canary":                 "true",
+			"nginx.ingress.kubernetes.io/canary-by-header":       "X-Variant",
+			"nginx.ingress.kubernetes.io/canary-by-header-value": "b",
+			"nginx.ingress.kubernetes.io/canary-by-cookie":       "variant-b",
+		}))
+	})
+
+	It("Should reject matches which cannot be expressed", func() {
+		for _, matches := range [][]ingress.Match{
+			{{Type: ingress.MatchHeader, Name: "X-Variant", Value: "b"}, {Type: ingress.MatchHeader, Name: "X-Beta", Value: "true"}},
+			{{Type: ingress.MatchCookie, Name: "variant", Value: "b"}},
+			{{Type: ingress.MatchQueryParam, Name: "variant", Value: "b"}},
+		} {
+			_, err := ingress.Nginx{}.MatchAnnotations(matches)
+			Expect(err).To(MatchError(ingress.UnsupportedErr))
+		}
+	})
+})
+
+var _ = Describe("Traefik translator", func() {
+	It("Should translate backend protocol to Service annotations", func() {
+		annotations, err := ingress.Traefik{}.OptionAnnotations(ingress.Options{BackendProtocol: ingress.ProtocolGRPC})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotations.Ingress).To(BeEmpty())
+		Expect(annotations.Service).To(Equal(map[string]string{
+			"traefik.ingress.kubernetes.io/service.serversscheme": "h2c",
+		}))
+	})
+
+	It("Should reject features which require Traefik resources", func() {
+		_, err := ingress.Traefik{}.OptionAnnotations(ingress.Options{ReadTimeout: utils.Pointer(time.Minute)})
+		Expect(err).To(MatchError(ingress.UnsupportedErr))
+		Expect(err).To(MatchError(ContainSubstring("timeouts")))
+		_, err = ingress.Traefik{}.RedirectAnnotations("https", "example.host")
+		Expect(err).To(MatchError(ingress.UnsupportedErr))
+		_, err = ingress.Traefik{}.WeightAnnotations(20)
+		Expect(err).To(MatchError(ingress.UnsupportedErr))
+		_, err = ingress.Traefik{}.MatchAnnotations([]ingress.Match{{Type: ingress.MatchHeader, Name: "X-Variant", Value: "b"}})
+		Expect(err).To(MatchError(ingress.UnsupportedErr))
+	})
+})
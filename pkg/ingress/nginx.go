@@ -0,0 +1,122 @@
+package ingress
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const nginxAnnotationPrefix = "nginx.ingress.kubernetes.io/" // nginxAnnotationPrefix defines the prefix of ingress-nginx annotations
+
+const (
+	nginxCanaryAnnotation              = nginxAnnotationPrefix + "canary"                 // nginxCanaryAnnotation marks canary Ingress
+	nginxCanaryWeightAnnotation        = nginxAnnotationPrefix + "canary-weight"          // nginxCanaryWeightAnnotation defines canary traffic share
+	nginxCanaryByHeaderAnnotation      = nginxAnnotationPrefix + "canary-by-header"       // nginxCanaryByHeaderAnnotation defines the header routed to canary
+	nginxCanaryByHeaderValueAnnotation = nginxAnnotationPrefix + "canary-by-header-value" // nginxCanaryByHeaderValueAnnotation defines the header value routed to canary
+	nginxCanaryByCookieAnnotation      = nginxAnnotationPrefix + "canary-by-cookie"       // nginxCanaryByCookieAnnotation defines the cookie routed to canary
+	nginxPermanentRedirectAnnotation   = nginxAnnotationPrefix + "permanent-redirect"     // nginxPermanentRedirectAnnotation defines redirect target
+
+	// nginxCookieAlways defines the only cookie value routed to canary by ingress-nginx
+	nginxCookieAlways = "always"
+)
+
+// Nginx translates routing features to ingress-nginx annotations
+type Nginx struct{}
+
+func (Nginx) Controller() string { return "ingress-nginx" }
+
+// OptionAnnotations translates options to Ingress annotations. Durations are rounded up to seconds.
+func (Nginx) OptionAnnotations(options Options) (Annotations, error) {
+	annotations := make(map[string]string)
+	if options.MaxBodySize != nil {
+		annotations[nginxAnnotationPrefix+"proxy-body-size"] = strconv.FormatInt(*options.MaxBodySize, 10)
+	}
+	for name, timeout := range map[string]*time.Duration{
+		"proxy-connect-timeout": options.ConnectTimeout,
+		"proxy-read-timeout":    options.ReadTimeout,
+		"proxy-send-timeout":    options.SendTimeout,
+	} {
+		if timeout != nil {
+			annotations[nginxAnnotationPrefix+name] = nginxSeconds(*timeout)
+		}
+	}
+	if options.HTTPSRedirect != nil {
+		annotations[nginxAnnotationPrefix+"ssl-redirect"] = strconv.FormatBool(*options.HTTPSRedirect)
+		annotations[nginxAnnotationPrefix+"force-ssl-redirect"] = strconv.FormatBool(*options.HTTPSRedirect)
+	}
+	if cors := options.CORS; cors != nil {
+		annotations[nginxAnnotationPrefix+"enable-cors"] = "true"
+		annotations[nginxAnnotationPrefix+"cors-allow-origin"] = strings.Join(cors.AllowOrigins, ", ")
+		annotations[nginxAnnotationPrefix+"cors-allow-credentials"] = strconv.FormatBool(cors.AllowCredentials)
+		if len(cors.AllowMethods) > 0 {
+			annotations[nginxAnnotationPrefix+"cors-allow-methods"] = strings.Join(cors.AllowMethods, ", ")
+		}
+		if len(cors.AllowHeaders) > 0 {
+			annotations[nginxAnnotationPrefix+"cors-allow-headers"] = strings.Join(cors.AllowHeaders, ", ")
+		}
+		if cors.MaxAge != nil {
+			annotations[nginxAnnotationPrefix+"cors-max-age"] = nginxSeconds(*cors.MaxAge)
+		}
+	}
+	if limit := options.RateLimit; limit != nil {
+		if limit.RequestsPerSecond != nil {
+			annotations[nginxAnnotationPrefix+"limit-rps"] = strconv.Itoa(int(*limit.RequestsPerSecond))
+		}
+		if limit.Connections != nil {
+			annotations[nginxAnnotationPrefix+"limit-connections"] = strconv.Itoa(int(*limit.Connections))
+		}
+	}
+	if options.BackendProtocol != "" {
+		annotations[nginxAnnotationPrefix+"backend-protocol"] = string(options.BackendProtocol)
+	}
+	return Annotations{Ingress: annotations}, nil
+}
+
+func (Nginx) RedirectAnnotations(scheme, host string) (map[string]string, error) {
+	return map[string]string{
+		nginxPermanentRedirectAnnotation: fmt.Sprintf("%s://%s$request_uri", scheme, host),
+	}, nil
+}
+
+func (Nginx) WeightAnnotations(weight int32) (map[string]string, error) {
+	return map[string]string{
+		nginxCanaryAnnotation:       "true",
+		nginxCanaryWeightAnnotation: strconv.Itoa(int(weight)),
+	}, nil
+}
+
+// MatchAnnotations translates matches to canary annotations. ingress-nginx supports a single
+// header match and a single cookie match with value "always", and no query parameter matches.
+func (Nginx) MatchAnnotations(matches []Match) (map[string]string, error) {
+	annotations := map[string]string{nginxCanaryAnnotation: "true"}
+	for _, match := range matches {
+		switch match.Type {
+		case MatchHeader:
+			if _, ok := annotations[nginxCanaryByHeaderAnnotation]; ok {
+				return nil, fmt.Errorf("%w: ingress-nginx supports a single header match", UnsupportedErr)
+			}
+			annotations[nginxCanaryByHeaderAnnotation] = match.Name
+			annotations[nginxCanaryByHeaderValueAnnotation] = match.Value
+
+		case MatchCookie:
+			if _, ok := annotations[nginxCanaryByCookieAnnotation]; ok {
+				return nil, fmt.Errorf("%w: ingress-nginx supports a single cookie match", UnsupportedErr)
+			}
+			if match.Value != nginxCookieAlways {
+				return nil, fmt.Errorf("%w: ingress-nginx matches cookies only with value %q", UnsupportedErr, nginxCookieAlways)
+			}
+			annotations[nginxCanaryByCookieAnnotation] = match.Name
+
+		default:
+			return nil, fmt.Errorf("%w: ingress-nginx does not support %s matches", UnsupportedErr, match.Type)
+		}
+	}
+	return annotations, nil
+}
+
+// nginxSeconds formats duration as whole seconds, rounded up
+func nginxSeconds(duration time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(duration.Seconds())))
+}
@@ -0,0 +1,55 @@
+package ingress
+
+import "fmt"
+
+// traefikServersSchemeAnnotation defines the scheme used by Traefik to connect to Service pods
+const traefikServersSchemeAnnotation = "traefik.ingress.kubernetes.io/service.serversscheme"
+
+// Traefik translates routing features to Traefik annotations. Traefik expresses most features
+// with Middleware resources, which are not managed by translators, and only supports backend
+// protocols with Service annotations.
+type Traefik struct{}
+
+func (Traefik) Controller() string { return "traefik" }
+
+func (Traefik) OptionAnnotations(options Options) (Annotations, error) {
+	var unsupported string
+	switch {
+	case options.MaxBodySize != nil:
+		unsupported = "maxBodySize"
+	case options.ConnectTimeout != nil || options.ReadTimeout != nil || options.SendTimeout != nil:
+		unsupported = "timeouts"
+	case options.HTTPSRedirect != nil:
+		unsupported = "httpsRedirect"
+	case options.CORS != nil:
+		unsupported = "cors"
+	case options.RateLimit != nil:
+		unsupported = "rateLimit"
+	}
+	if unsupported != "" {
+		return Annotations{}, fmt.Errorf("%w: traefik requires Middleware resources for %s", UnsupportedErr, unsupported)
+	}
+
+	annotations := make(map[string]string)
+	switch options.BackendProtocol {
+	case ProtocolHTTP:
+		annotations[traefikServersSchemeAnnotation] = "http"
+	case ProtocolHTTPS, ProtocolGRPCS:
+		annotations[traefikServersSchemeAnnotation] = "https"
+	case ProtocolGRPC:
+		annotations[traefikServersSchemeAnnotation] = "h2c"
+	}
+	return Annotations{Service: annotations}, nil
+}
+
+func (Traefik) RedirectAnnotations(_, _ string) (map[string]string, error) {
+	return nil, fmt.Errorf("%w: traefik requires Middleware resources for redirects", UnsupportedErr)
+}
+
+func (Traefik) WeightAnnotations(_ int32) (map[string]string, error) {
+	return nil, fmt.Errorf("%w: traefik requires TraefikService resources for weighted traffic", UnsupportedErr)
+}
+
+func (Traefik) MatchAnnotations(_ []Match) (map[string]string, error) {
+	return nil, fmt.Errorf("%w: traefik requires IngressRoute resources for request matches", UnsupportedErr)
+}
@@ -14,6 +14,7 @@ const (
 	Create
 	Update
 	Check
+	Delete
 )
 
 var opsMap = map[Operation]string{
@@ -22,6 +23,7 @@ var opsMap = map[Operation]string{
 	Create: "Create",
 	Update: "Update",
 	Check:  "Check",
+	Delete: "Delete",
 }
 
 func (o Operation) String() string {
@@ -37,6 +39,20 @@ var (
 	OperationNotReadyErr       = errors.New("operation not ready yet")
 )
 
+// NotReadyError is an OperationNotReadyErr with the reason why the operation is not ready yet.
+type NotReadyError struct {
+	Reason string
+}
+
+// NewNotReadyError creates NotReadyError for the given reason
+func NewNotReadyError(reason string) error {
+	return &NotReadyError{Reason: reason}
+}
+
+func (e *NotReadyError) Error() string { return e.Reason }
+
+func (e *NotReadyError) Is(target error) bool { return target == OperationNotReadyErr }
+
 // Executor simplifies synchronization logic for a requested resource.
 // It exposes a simple Execute method which processes resource lifecycle.
 type Executor[T client.Object] struct {
@@ -45,11 +61,22 @@ type Executor[T client.Object] struct {
 	CreateFunc func(ctx context.Context, obj T) error
 	UpdateFunc func(ctx context.Context, obj T) (bool, error)
 	IsReady    func(ctx context.Context, obj T) bool
+	DeleteFunc func(ctx context.Context, obj T) error
+
+	// StatusFunc is an optional function called when the object is not ready.
+	// Returns an error if the object cannot become ready without changes, e.g.
+	// when it is stuck, to stop waiting and report the failure.
+	// Return NotReadyError to report the progress instead.
+	StatusFunc func(ctx context.Context, obj T) error
 
 	// nop indicates that no operation will be performed during Execute.
 	// Specify when Executor should do nothing.
 	// Private field and can only be used with NopExecutor.
 	nop bool
+
+	// remove indicates that the object will be deleted during Execute if it exists.
+	// Private field and can only be used with RemoveExecutor.
+	remove bool
 }
 
 // NopExecutor is noop executor for workflows. It can be used to indicate
@@ -61,6 +88,18 @@ func NopExecutor[T client.Object](name string) Executor[T] {
 	}
 }
 
+// RemoveExecutor is removal executor for workflows. It can be used to indicate
+// that requested operation is valid, but the object should no longer exist.
+// Requires FetchFunc and DeleteFunc, other functions are ignored.
+func RemoveExecutor[T client.Object](name string, fetchFunc, deleteFunc func(ctx context.Context, obj T) error) Executor[T] {
+	return Executor[T]{
+		Name:       name,
+		FetchFunc:  fetchFunc,
+		DeleteFunc: deleteFunc,
+		remove:     true,
+	}
+}
+
 // Execute performs the resource execution by invoking Executor functions in ordered manner.
 // Returns an error if data is missing or for runtime operations.
 // Returns all the operations performed during execution.
@@ -74,6 +113,9 @@ func (h *Executor[T]) Execute(ctx context.Context, obj T) ExecuteResult {
 	if h.nop {
 		return results.Add(Skip)
 	}
+	if h.remove {
+		return h.executeRemove(ctx, obj, results)
+	}
 	if op, err := h.validate(); err != nil {
 		return results.AddWithErr(op, err)
 	}
@@ -110,9 +152,38 @@ func (h *Executor[T]) Execute(ctx context.Context, obj T) ExecuteResult {
 	if h.IsReady(ctx, obj) {
 		return results.Add(Check)
 	}
+	if h.StatusFunc != nil {
+		if err := h.StatusFunc(ctx, obj); err != nil {
+			return results.AddWithErr(Check, err) // critical status error occurred
+		}
+	}
 	return results.AddWithErr(Check, OperationNotReadyErr)
 }
 
+// executeRemove deletes the object if it exists. Returns skipped results on success.
+func (h *Executor[T]) executeRemove(ctx context.Context, obj T, results ExecuteResult) ExecuteResult {
+	switch {
+	case h.FetchFunc == nil:
+		return results.AddWithErr(Fetch, MissingHandlerResourcesErr)
+	case h.DeleteFunc == nil:
+		return results.AddWithErr(Delete, MissingHandlerResourcesErr)
+	}
+
+	// Fetch the object
+	if err := h.FetchFunc(ctx, obj); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return results.Add(Skip) // nothing to remove
+		}
+		return results.AddWithErr(Fetch, err) // critical fetch error occurred
+	}
+
+	// Delete the object
+	if err := h.DeleteFunc(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return results.AddWithErr(Delete, err) // critical delete error occurred
+	}
+	return results.Add(Skip | Delete)
+}
+
 func (h *Executor[T]) validate() (Operation, error) {
 	switch {
 	case h.FetchFunc == nil:
@@ -153,12 +224,21 @@ func (r ExecuteResult) AddWithErr(op Operation, err error) ExecuteResult {
 
 // Error returns the errored operation
 func (r ExecuteResult) Error() error {
-	if r.err != nil && r.err != OperationNotReadyErr {
+	if r.err != nil && !errors.Is(r.err, OperationNotReadyErr) {
 		return r.err
 	}
 	return nil
 }
 
+// NotReadyReason returns the reason why the object is not ready, if reported.
+func (r ExecuteResult) NotReadyReason() string {
+	var notReadyErr *NotReadyError
+	if r.NotReady() && errors.As(r.err, &notReadyErr) {
+		return notReadyErr.Reason
+	}
+	return ""
+}
+
 func (r ExecuteResult) Errored() bool {
 	return r.Error() != nil
 }
@@ -172,17 +252,20 @@ func (r ExecuteResult) Ready() bool {
 }
 
 func (r ExecuteResult) NotReady() bool {
-	return r.op&Check != 0 && r.err == OperationNotReadyErr
+	return r.op&Check != 0 && errors.Is(r.err, OperationNotReadyErr)
 }
 
 // ProcessingOps returns processing operations performed.
 func (r ExecuteResult) ProcessingOps() []string {
-	results := make([]string, 0, 2)
+	results := make([]string, 0, 3)
 	if r.op&Create != 0 {
 		results = append(results, opsMap[Create])
 	}
 	if r.op&Update != 0 {
 		results = append(results, opsMap[Update])
 	}
+	if r.op&Delete != 0 {
+		results = append(results, opsMap[Delete])
+	}
 	return results
 }
@@ -13,6 +13,16 @@ func MergeMapsSrcDst(from, to map[string]string) {
 	}
 }
 
+// MapContains returns true if all entries from subset are present in source
+func MapContains(source, subset map[string]string) bool {
+	for key, value := range subset {
+		if sourceValue, ok := source[key]; !ok || sourceValue != value {
+			return false
+		}
+	}
+	return true
+}
+
 // UnsafeMapDiff just returns a diff between two objects. Must pass a reference.
 // TODO: Resolve this for better usage by adding depth/recursive search
 func UnsafeMapDiff(objA, objB interface{}) (diffValues, error) {
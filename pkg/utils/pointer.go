@@ -0,0 +1,6 @@
+package utils
+
+// Pointer returns a pointer to a copy of the value
+func Pointer[T any](value T) *T {
+	return &value
+}